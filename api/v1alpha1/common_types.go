@@ -15,3 +15,44 @@ const (
 	// Unmanaged status when the system is not sure what to do
 	Unmanaged ConfigState = "Unmanaged"
 )
+
+// ConditionType is the type of a condition of the Linkerd resource
+type ConditionType string
+
+const (
+	// ConditionVersionSupported is true when the version of Linkerd is in the release catalog
+	ConditionVersionSupported ConditionType = "VersionSupported"
+	// ConditionTapAPIAvailable mirrors the Available condition of the tap APIService
+	ConditionTapAPIAvailable ConditionType = "TapAPIAvailable"
+	// ConditionControlPlaneFound is true when a control plane serves the multicluster namespace of a Link
+	ConditionControlPlaneFound ConditionType = "ControlPlaneFound"
+	// ConditionRemoteClusterReachable is true when the API server of the remote cluster of a Link answers
+	ConditionRemoteClusterReachable ConditionType = "RemoteClusterReachable"
+	// ConditionIdentityIssuerVerified is true when the identity issuer chains to the shared trust anchors
+	ConditionIdentityIssuerVerified ConditionType = "IdentityIssuerVerified"
+	// ConditionRevisionUnique is true when no older Linkerd resource installs the control plane of the same revision
+	ConditionRevisionUnique ConditionType = "RevisionUnique"
+)
+
+// UpgradePhase describes the progress of an upgrade
+type UpgradePhase string
+
+const (
+	// UpgradeProgressing while the components are upgraded one after the other
+	UpgradeProgressing UpgradePhase = "Progressing"
+	// UpgradeCompleted once every component runs the new version
+	UpgradeCompleted UpgradePhase = "Completed"
+	// UpgradeRolledBack when a component did not become ready in time and the previous version was restored,
+	// until the spec changes
+	UpgradeRolledBack UpgradePhase = "RolledBack"
+)
+
+// IptablesMode describes the iptables backend used by proxy-init
+type IptablesMode string
+
+const (
+	// IptablesModeLegacy uses the legacy iptables binaries
+	IptablesModeLegacy IptablesMode = "legacy"
+	// IptablesModeNft uses the nftables based iptables binaries
+	IptablesModeNft IptablesMode = "nft"
+)
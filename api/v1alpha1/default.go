@@ -1,18 +1,21 @@
 package v1alpha1
 
 import (
+	"time"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/certs"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	linkerdImageHub               = "docker.io/davideberdin"
 	linkerdImageVersion           = "v0.1.0"
-	defaultImageHub               = "gcr.io/linkerd-io"
-	defaultImageVersion           = "stable-2.8.1"
 	defaultCollectorImageHub      = "omnition"
 	defaultCollectorImageVersion  = "0.1.11"
 	defaultJaegerImageHub         = "jaegertracing"
@@ -20,19 +23,43 @@ const (
 	defaultImagePullPolicy        = "IfNotPresent"
 	defaultNetworkName            = "cluster.local"
 	defaultLogLevel               = "log-level:info"
+	defaultCNILogLevel            = "info"
+	defaultCNINetDir              = "/etc/cni/net.d"
+	defaultCNIBinDir              = "/opt/cni/bin"
 	defaultPrometheusImageHub     = "prom"
 	defaultPrometheusImageVersion = "v2.15.2"
+	defaultSamplingPercentage     = 100
+	defaultWebhookTimeoutSeconds  = 10
+	defaultScrapeInterval         = "10s"
+	defaultRetentionTime          = "6h"
+	defaultPrometheusStorageSize  = "8Gi"
+	defaultAlertsFor              = "5m"
+	defaultHeartbeatSchedule      = "16 8 * * *"
+	defaultComponentTimeout       = 10 * time.Minute
+	defaultBatchInterval          = 5 * time.Minute
+	defaultBatchSize              = 1
+	defaultMaxConcurrent          = 2
+	defaultCertificateExpiry      = "6h"
+	defaultProxyErrorRate         = 5
+	// multicluster
+	defaultMulticlusterNamespace      = "linkerd-multicluster"
+	defaultGatewayName                = "linkerd-gateway"
+	defaultGatewayPort                = 4143
+	defaultGatewayProbePort           = 4181
+	defaultGatewayProbePath           = "/health"
+	defaultGatewayProbeSeconds        = 3
+	defaultRemoteMirrorServiceAccount = "linkerd-service-mirror-remote-access-default"
+	defaultServiceMirrorRequeueLimit  = 3
+	defaultTargetClusterLinkerdNs     = "linkerd"
 	// replicas
 	defaultReplicaCount = 1
 	defaultMinReplicas  = 1
 	defaultMaxReplicas  = 5
 	// images
-	defaultControllerImage = defaultImageHub + "/" + "controller" + ":" + defaultImageVersion
-	defaultWebImage        = defaultImageHub + "/" + "web" + ":" + defaultImageVersion
-	defaultGrafanaImage    = defaultImageHub + "/" + "grafana" + ":" + defaultImageVersion
 	defaultCollectorImage  = defaultCollectorImageHub + "/" + "opencensus-collector" + ":" + defaultCollectorImageVersion
 	defaultJaegerImage     = defaultJaegerImageHub + "/" + "all-in-one" + ":" + defaultJaegerImageVersion
 	defaultPrometheusImage = defaultPrometheusImageHub + "/" + "prometheus" + ":" + defaultPrometheusImageVersion
+	defaultGatewayImage    = "nginx:1.17"
 	// resources
 )
 
@@ -51,26 +78,43 @@ var defaultResources = &apiv1.ResourceRequirements{
 // 	{ServicePort: corev1.ServicePort{Name: "http", Port: int32(8085), TargetPort: intstr.FromString("8085")}},
 // }
 
-// SetDefaults sets the defaults values for all the components
-func SetDefaults(config *Linkerd) {
-	// certs
-	if config.Spec.SelfSignedCertificates == nil {
-		// create certs here and dispatch them in the configs
-		it, err := certs.GenerateTrustAnchorsCertificates(defaultNetworkName)
-		// TODO: fix this
-		if err != nil {
-			panic(err)
-		}
-		// set certs with newly generated ones
-		config.Spec.SelfSignedCertificates = &SelfSignedCertificates{
-			TrustAnchorsPEM: it.TrustAnchorsPEM,
-			KeyPEM:          it.KeyPEM,
-			CrtPEM:          it.CrtPEM,
-		}
+// GenerateSelfSignedCertificates generates trust anchors along with an issuer for the cluster
+func GenerateSelfSignedCertificates() (*SelfSignedCertificates, error) {
+	it, err := certs.GenerateTrustAnchorsCertificates(defaultNetworkName)
+	if err != nil {
+		return nil, err
+	}
+	return &SelfSignedCertificates{
+		TrustAnchorsPEM: it.TrustAnchorsPEM,
+		KeyPEM:          it.KeyPEM,
+		CrtPEM:          it.CrtPEM,
+	}, nil
+}
+
+// SetDefaults sets the defaults values for all the components, using the images of the given release
+func SetDefaults(config *Linkerd, release catalog.Release) {
+	controllerImage := release.Image(release.Images.Controller)
+
+	// certs are generated by the operator in the certificates Secret, never in the spec
+	// cni
+	if config.Spec.CNI.Image == nil {
+		config.Spec.CNI.Image = util.StrPointer(release.Image(release.Images.CNI))
+	}
+	if config.Spec.CNI.Resources == nil {
+		config.Spec.CNI.Resources = defaultResources
+	}
+	if config.Spec.CNI.DestCNINetDir == "" {
+		config.Spec.CNI.DestCNINetDir = defaultCNINetDir
+	}
+	if config.Spec.CNI.DestCNIBinDir == "" {
+		config.Spec.CNI.DestCNIBinDir = defaultCNIBinDir
+	}
+	if config.Spec.CNI.LogLevel == "" {
+		config.Spec.CNI.LogLevel = defaultCNILogLevel
 	}
 	// controller
 	if config.Spec.Controller.Image == nil {
-		config.Spec.Controller.Image = util.StrPointer(defaultControllerImage)
+		config.Spec.Controller.Image = util.StrPointer(controllerImage)
 	}
 	if config.Spec.Controller.Resources == nil {
 		config.Spec.Controller.Resources = defaultResources
@@ -78,20 +122,102 @@ func SetDefaults(config *Linkerd) {
 	if config.Spec.Controller.Resources == nil {
 		config.Spec.Controller.Resources = defaultResources
 	}
+	// data plane
+	if config.Spec.DataPlane.BatchSize == nil {
+		config.Spec.DataPlane.BatchSize = util.IntPointer(defaultBatchSize)
+	}
+	if config.Spec.DataPlane.BatchInterval == nil {
+		config.Spec.DataPlane.BatchInterval = &metav1.Duration{Duration: defaultBatchInterval}
+	}
+	if config.Spec.DataPlane.MaxConcurrent == nil {
+		config.Spec.DataPlane.MaxConcurrent = util.IntPointer(defaultMaxConcurrent)
+	}
 	// destination
 	if config.Spec.Destination.Image == nil {
-		config.Spec.Destination.Image = util.StrPointer(defaultControllerImage)
+		config.Spec.Destination.Image = util.StrPointer(controllerImage)
 	}
 	if config.Spec.Destination.Resources == nil {
 		config.Spec.Destination.Resources = defaultResources
 	}
+	// grafana
+	if config.Spec.Grafana.Enabled == nil {
+		config.Spec.Grafana.Enabled = util.BoolPointer(true)
+	}
+	if config.Spec.Grafana.Image == nil {
+		config.Spec.Grafana.Image = util.StrPointer(release.Image(release.Images.Grafana))
+	}
+	if config.Spec.Grafana.Resources == nil {
+		config.Spec.Grafana.Resources = defaultResources
+	}
+	// heartbeat
+	if config.Spec.Heartbeat.Enabled == nil {
+		config.Spec.Heartbeat.Enabled = util.BoolPointer(true)
+	}
+	if config.Spec.Heartbeat.Image == nil {
+		config.Spec.Heartbeat.Image = util.StrPointer(controllerImage)
+	}
+	if config.Spec.Heartbeat.Schedule == "" {
+		config.Spec.Heartbeat.Schedule = defaultHeartbeatSchedule
+	}
+	if config.Spec.Heartbeat.Resources == nil {
+		config.Spec.Heartbeat.Resources = &apiv1.ResourceRequirements{
+			Limits: apiv1.ResourceList{
+				apiv1.ResourceCPU:    resource.MustParse("1"),
+				apiv1.ResourceMemory: resource.MustParse("250Mi"),
+			},
+			Requests: apiv1.ResourceList{
+				apiv1.ResourceCPU:    resource.MustParse("100m"),
+				apiv1.ResourceMemory: resource.MustParse("50Mi"),
+			},
+		}
+	}
 	// identity
 	if config.Spec.Identity.Image == nil {
-		config.Spec.Identity.Image = util.StrPointer(defaultControllerImage)
+		config.Spec.Identity.Image = util.StrPointer(controllerImage)
 	}
 	if config.Spec.Identity.Resources == nil {
 		config.Spec.Identity.Resources = defaultResources
 	}
+	// multicluster
+	if config.Spec.Multicluster.Namespace == "" {
+		config.Spec.Multicluster.Namespace = defaultMulticlusterNamespace
+	}
+	if config.Spec.Multicluster.Gateway.Image == nil {
+		config.Spec.Multicluster.Gateway.Image = util.StrPointer(defaultGatewayImage)
+	}
+	if config.Spec.Multicluster.Gateway.Resources == nil {
+		config.Spec.Multicluster.Gateway.Resources = defaultResources
+	}
+	if config.Spec.Multicluster.Gateway.Port == nil {
+		config.Spec.Multicluster.Gateway.Port = util.IntPointer(defaultGatewayPort)
+	}
+	if config.Spec.Multicluster.Gateway.ProbePort == nil {
+		config.Spec.Multicluster.Gateway.ProbePort = util.IntPointer(defaultGatewayProbePort)
+	}
+	if config.Spec.Multicluster.Gateway.ProbePath == "" {
+		config.Spec.Multicluster.Gateway.ProbePath = defaultGatewayProbePath
+	}
+	if config.Spec.Multicluster.Gateway.ProbeSeconds == nil {
+		config.Spec.Multicluster.Gateway.ProbeSeconds = util.IntPointer(defaultGatewayProbeSeconds)
+	}
+	if config.Spec.Multicluster.Gateway.ServiceType == "" {
+		config.Spec.Multicluster.Gateway.ServiceType = apiv1.ServiceTypeLoadBalancer
+	}
+	if config.Spec.Multicluster.ServiceMirror.Image == nil {
+		config.Spec.Multicluster.ServiceMirror.Image = util.StrPointer(controllerImage)
+	}
+	if config.Spec.Multicluster.ServiceMirror.Resources == nil {
+		config.Spec.Multicluster.ServiceMirror.Resources = defaultResources
+	}
+	if config.Spec.Multicluster.ServiceMirror.LogLevel == "" {
+		config.Spec.Multicluster.ServiceMirror.LogLevel = "info"
+	}
+	if config.Spec.Multicluster.ServiceMirror.EventRequeueLimit == nil {
+		config.Spec.Multicluster.ServiceMirror.EventRequeueLimit = util.IntPointer(defaultServiceMirrorRequeueLimit)
+	}
+	if config.Spec.Multicluster.RemoteMirrorServiceAccountName == "" {
+		config.Spec.Multicluster.RemoteMirrorServiceAccountName = defaultRemoteMirrorServiceAccount
+	}
 	// prometheus
 	if config.Spec.Prometheus.Image == nil {
 		config.Spec.Prometheus.Image = util.StrPointer(defaultPrometheusImage)
@@ -108,31 +234,129 @@ func SetDefaults(config *Linkerd) {
 			},
 		}
 	}
+	if config.Spec.Prometheus.ScrapeInterval == "" {
+		config.Spec.Prometheus.ScrapeInterval = defaultScrapeInterval
+	}
+	if config.Spec.Prometheus.Persistence.RetentionTime == "" {
+		config.Spec.Prometheus.Persistence.RetentionTime = defaultRetentionTime
+	}
+	if config.Spec.Prometheus.Persistence.Size == nil {
+		size := resource.MustParse(defaultPrometheusStorageSize)
+		config.Spec.Prometheus.Persistence.Size = &size
+	}
+	if config.Spec.Prometheus.Alerts.Enabled == nil {
+		config.Spec.Prometheus.Alerts.Enabled = util.BoolPointer(true)
+	}
+	if config.Spec.Prometheus.Alerts.ComponentDownFor == "" {
+		config.Spec.Prometheus.Alerts.ComponentDownFor = defaultAlertsFor
+	}
+	if config.Spec.Prometheus.Alerts.FailuresFor == "" {
+		config.Spec.Prometheus.Alerts.FailuresFor = defaultAlertsFor
+	}
+	if config.Spec.Prometheus.Alerts.CertificateExpiryThreshold == "" {
+		config.Spec.Prometheus.Alerts.CertificateExpiryThreshold = defaultCertificateExpiry
+	}
+	if config.Spec.Prometheus.Alerts.ProxyErrorRatePercentage == nil {
+		config.Spec.Prometheus.Alerts.ProxyErrorRatePercentage = util.IntPointer(defaultProxyErrorRate)
+	}
 	// proxyinjector
 	if config.Spec.ProxyInjector.Image == nil {
-		config.Spec.ProxyInjector.Image = util.StrPointer(defaultControllerImage)
+		config.Spec.ProxyInjector.Image = util.StrPointer(controllerImage)
 	}
 	if config.Spec.ProxyInjector.Resources == nil {
 		config.Spec.ProxyInjector.Resources = defaultResources
 	}
+	if config.Spec.ProxyInjector.FailurePolicy == "" {
+		config.Spec.ProxyInjector.FailurePolicy = admissionregistrationv1.Ignore
+		if config.Spec.IsHighlyAvailable() {
+			config.Spec.ProxyInjector.FailurePolicy = admissionregistrationv1.Fail
+		}
+	}
+	if config.Spec.ProxyInjector.NamespaceSelector == nil {
+		config.Spec.ProxyInjector.NamespaceSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      "config.linkerd.io/admission-webhooks",
+					Operator: metav1.LabelSelectorOpNotIn,
+					Values:   []string{"disabled"},
+				},
+			},
+		}
+	}
+	if config.Spec.ProxyInjector.TimeoutSeconds == nil {
+		config.Spec.ProxyInjector.TimeoutSeconds = util.IntPointer(defaultWebhookTimeoutSeconds)
+	}
+	if config.Spec.ProxyInjector.ReinvocationPolicy == "" {
+		config.Spec.ProxyInjector.ReinvocationPolicy = admissionregistrationv1.NeverReinvocationPolicy
+	}
+	// proxy-init
+	if config.Spec.ProxyInit.IptablesMode == "" {
+		config.Spec.ProxyInit.IptablesMode = IptablesModeLegacy
+	}
 	// psp
 
 	// serviceprofile
 
+	// sp-validator
+	if config.Spec.SPValidator.Image == nil {
+		config.Spec.SPValidator.Image = util.StrPointer(controllerImage)
+	}
+	if config.Spec.SPValidator.Resources == nil {
+		config.Spec.SPValidator.Resources = defaultResources
+	}
+	if config.Spec.SPValidator.FailurePolicy == "" {
+		config.Spec.SPValidator.FailurePolicy = admissionregistrationv1.Ignore
+	}
 	// tap
 	if config.Spec.Tap.Image == nil {
-		config.Spec.Tap.Image = util.StrPointer(defaultControllerImage)
+		config.Spec.Tap.Image = util.StrPointer(controllerImage)
 	}
 	if config.Spec.Tap.Resources == nil {
 		config.Spec.Tap.Resources = defaultResources
 	}
+	// tracing
+	if config.Spec.Tracing.Collector.Image == nil {
+		config.Spec.Tracing.Collector.Image = util.StrPointer(defaultCollectorImage)
+	}
+	if config.Spec.Tracing.Collector.Resources == nil {
+		config.Spec.Tracing.Collector.Resources = defaultResources
+	}
+	if config.Spec.Tracing.Collector.SamplingPercentage == nil {
+		config.Spec.Tracing.Collector.SamplingPercentage = util.IntPointer(defaultSamplingPercentage)
+	}
+	if config.Spec.Tracing.Jaeger.Image == nil {
+		config.Spec.Tracing.Jaeger.Image = util.StrPointer(defaultJaegerImage)
+	}
+	if config.Spec.Tracing.Jaeger.Resources == nil {
+		config.Spec.Tracing.Jaeger.Resources = defaultResources
+	}
 	// trafficsplit
 
+	// upgrade
+	if config.Spec.Upgrade.ComponentTimeout == nil {
+		config.Spec.Upgrade.ComponentTimeout = &metav1.Duration{Duration: defaultComponentTimeout}
+	}
 	// web
 	if config.Spec.Web.Image == nil {
-		config.Spec.Web.Image = util.StrPointer(defaultWebImage)
+		config.Spec.Web.Image = util.StrPointer(release.Image(release.Images.Web))
 	}
 	if config.Spec.Web.Resources == nil {
 		config.Spec.Web.Resources = defaultResources
 	}
 }
+
+// SetLinkDefaults sets the defaults values of a Link
+func SetLinkDefaults(link *Link) {
+	if link.Spec.TargetClusterDomain == "" {
+		link.Spec.TargetClusterDomain = defaultNetworkName
+	}
+	if link.Spec.TargetClusterLinkerdNamespace == "" {
+		link.Spec.TargetClusterLinkerdNamespace = defaultTargetClusterLinkerdNs
+	}
+	if link.Spec.GatewayName == "" {
+		link.Spec.GatewayName = defaultGatewayName
+	}
+	if link.Spec.GatewayNamespace == "" {
+		link.Spec.GatewayNamespace = defaultMulticlusterNamespace
+	}
+}
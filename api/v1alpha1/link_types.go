@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Linkerd2 Operator authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LinkSpec defines the remote cluster whose services are mirrored. A Link is served by the control plane
+// whose multicluster namespace is the namespace of the Link
+type LinkSpec struct {
+	// TargetClusterName suffixes the names of the mirrored services
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	TargetClusterName string `json:"targetClusterName"`
+	// ClusterCredentialsSecret is the Secret of the namespace of the Link holding the kubeconfig
+	// of the remote cluster under the kubeconfig key
+	ClusterCredentialsSecret string `json:"clusterCredentialsSecret"`
+	// TargetClusterDomain is the cluster domain of the remote cluster
+	TargetClusterDomain string `json:"targetClusterDomain,omitempty"`
+	// TargetClusterLinkerdNamespace is the namespace of the control plane of the remote cluster
+	TargetClusterLinkerdNamespace string `json:"targetClusterLinkerdNamespace,omitempty"`
+	// GatewayName is the name of the gateway Service of the remote cluster
+	GatewayName string `json:"gatewayName,omitempty"`
+	// GatewayNamespace is the namespace of the gateway Service of the remote cluster
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+}
+
+// LinkStatus defines the observed state of Link
+type LinkStatus struct {
+	// Conditions are the latest observations of the Link
+	Conditions []LinkerdCondition `json:"conditions,omitempty"`
+	// Gateway records the state of the gateway of the remote cluster
+	Gateway *GatewayStatus `json:"gateway,omitempty"`
+	// LastProbeTime is when the remote cluster was last reached
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// SetCondition adds or updates the condition of the same type. The transition time
+// is only updated when the status changes
+func (s *LinkStatus) SetCondition(condition LinkerdCondition) {
+	s.Conditions = setCondition(s.Conditions, condition)
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Link is the Schema for the links API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.targetClusterName",description="Remote cluster"
+// +kubebuilder:printcolumn:name="Gateway",type="boolean",JSONPath=".status.gateway.alive",description="Whether the remote gateway is alive"
+// +kubebuilder:resource:path=links,scope=Namespaced
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Link struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LinkSpec   `json:"spec,omitempty"`
+	Status LinkStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LinkList contains a list of Link
+type LinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Link `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Link{}, &LinkList{})
+}
@@ -17,16 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"regexp"
-
 	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-const supportedLinkerdMinorVersionRegex = "^2.*"
-
 // ServicePort extends the corev1 ServicePort object
 type ServicePort struct {
 	corev1.ServicePort `json:",inline"`
@@ -72,24 +70,252 @@ type ControllerConfiguration struct {
 	BaseK8sResourceConfiguration `json:",inline"`
 }
 
+// DataPlaneConfiguration defines how the workloads are restarted to get the proxy of the current version
+type DataPlaneConfiguration struct {
+	// AutoRestart restarts the workloads running an outdated proxy, or no proxy in an injected namespace
+	AutoRestart bool `json:"autoRestart,omitempty"`
+	// BatchSize is the number of workloads restarted together
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *int32 `json:"batchSize,omitempty"`
+	// BatchInterval is the minimum time between two batches
+	BatchInterval *metav1.Duration `json:"batchInterval,omitempty"`
+	// MaxConcurrent is the maximum number of workloads rolling out at the same time
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+	// MaintenanceWindow restricts the restarts to a recurring window, restarts happen at any time when not set
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindow is a recurring time window, in UTC
+type MaintenanceWindow struct {
+	// Start of the window formatted as HH:MM
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration of the window
+	Duration metav1.Duration `json:"duration"`
+	// Days of the week the window opens, every day when empty
+	Days []Weekday `json:"days,omitempty"`
+}
+
+// Weekday is a day of the week
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type Weekday string
+
 // DestinationConfiguration defines the k8s spec configuration for the linkerd destination
 type DestinationConfiguration struct {
 	BaseK8sResourceConfiguration `json:",inline"`
 }
 
+// GrafanaConfiguration defines the k8s spec configuration for the grafana deployment
+type GrafanaConfiguration struct {
+	BaseK8sResourceConfiguration `json:",inline"`
+	// Enabled deploys the bundled grafana with the Linkerd dashboards
+	Enabled *bool `json:"enabled,omitempty"`
+	// ExternalAddr is the address (host:port) of an existing grafana instance the dashboard links to.
+	// When set, the bundled grafana is not deployed
+	ExternalAddr string `json:"externalAddr,omitempty"`
+}
+
+// HeartbeatConfiguration defines the k8s spec configuration for the heartbeat cronjob
+type HeartbeatConfiguration struct {
+	BaseK8sResourceConfiguration `json:",inline"`
+	// Enabled schedules the heartbeat reporting anonymous usage data to the Linkerd maintainers
+	Enabled *bool `json:"enabled,omitempty"`
+	// Schedule of the heartbeat in cron format
+	Schedule string `json:"schedule,omitempty"`
+	// Endpoint is an HTTP(S) relay the heartbeat is sent through, for clusters that cannot
+	// reach versioncheck.linkerd.io directly. It is passed to the job as HTTPS_PROXY
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
 // IdentityConfiguration defines the k8s spec configuration for the linkerd identity
 type IdentityConfiguration struct {
 	BaseK8sResourceConfiguration `json:",inline"`
+	// TrustAnchors references the PEM bundle of the roots shared with the other clusters. When set, the
+	// self signed certificates are replaced by the bundle and by the issuer of this cluster
+	TrustAnchors *TrustAnchorsReference `json:"trustAnchors,omitempty"`
+	// Issuer defines where the issuer of this cluster comes from, it must chain to the trust anchors
+	Issuer IssuerConfiguration `json:"issuer,omitempty"`
+	// CertificatesSecret is the Secret of the namespace holding the self signed certificates. The operator moves
+	// the certificates of selfSignedCerts to it, so that the Linkerd resource does not expose the keys
+	CertificatesSecret string `json:"certificatesSecret,omitempty"`
+}
+
+// TrustAnchorsReference references a key of a Secret or of a ConfigMap of the namespace of the Linkerd resource
+type TrustAnchorsReference struct {
+	Secret    *corev1.SecretKeySelector    `json:"secret,omitempty"`
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+}
+
+// IssuerConfiguration defines the issuer of the identity certificates. The Secrets are kubernetes.io/tls
+// Secrets of the namespace of the Linkerd resource, only one of them is set
+type IssuerConfiguration struct {
+	// SigningSecret holds a CA chaining to the trust anchors, the operator issues the issuer from it
+	SigningSecret string `json:"signingSecret,omitempty"`
+	// ExternalSecret holds an issuer signed outside of the operator, e.g. by cert-manager
+	ExternalSecret string `json:"externalSecret,omitempty"`
 }
 
 // PrometheusConfiguration defines the k8s spec configuration for the prometheus deployment
 type PrometheusConfiguration struct {
 	BaseK8sResourceConfiguration `json:",inline"`
+	// URL of an existing Prometheus queried by the control plane (e.g. http://prometheus.monitoring:9090).
+	// When set, the bundled prometheus is not deployed
+	URL string `json:"url,omitempty"`
+	// Credentials used to query the Prometheus referenced by URL
+	Credentials *PrometheusCredentials `json:"credentials,omitempty"`
+	// ScrapeInterval is how frequently the bundled prometheus scrapes its targets (e.g. 10s)
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+	// ExternalLabels are added to the time series and alerts sent to external systems
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+	// AdditionalScrapeConfigs is a YAML list of scrape configs appended to the Linkerd ones
+	AdditionalScrapeConfigs string `json:"additionalScrapeConfigs,omitempty"`
+	// RemoteWrite is the list of endpoints the samples are forwarded to
+	RemoteWrite []PrometheusRemoteWrite `json:"remoteWrite,omitempty"`
+	// RuleFiles are recording and alerting rule files, keyed by file name
+	RuleFiles map[string]string `json:"ruleFiles,omitempty"`
+	// Persistence configuration options
+	Persistence PrometheusPersistence `json:"persistence,omitempty"`
+	// Operator configures the integration with prometheus-operator
+	Operator PrometheusOperatorConfiguration `json:"operator,omitempty"`
+	// Alerts configures the control plane alerting rules
+	Alerts PrometheusAlertsConfiguration `json:"alerts,omitempty"`
+}
+
+// PrometheusAlertsConfiguration defines the thresholds of the control plane alerting rules
+type PrometheusAlertsConfiguration struct {
+	// Enabled ships the alerting rules, in the prometheus configuration or as a PrometheusRule
+	Enabled *bool `json:"enabled,omitempty"`
+	// ComponentDownFor is how long a control plane component is unreachable before alerting (e.g. 5m)
+	ComponentDownFor string `json:"componentDownFor,omitempty"`
+	// FailuresFor is how long identity issuance or proxy injection fail before alerting (e.g. 5m)
+	FailuresFor string `json:"failuresFor,omitempty"`
+	// CertificateExpiryThreshold is the minimum remaining validity of the proxy certificates (e.g. 6h).
+	// They are renewed well before expiring, so reaching it means the renewal is failing
+	CertificateExpiryThreshold string `json:"certificateExpiryThreshold,omitempty"`
+	// ProxyErrorRatePercentage is the percentage of failed responses of a workload above which to alert
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ProxyErrorRatePercentage *int32 `json:"proxyErrorRatePercentage,omitempty"`
+}
+
+// PrometheusOperatorConfiguration defines the integration with prometheus-operator
+type PrometheusOperatorConfiguration struct {
+	// Enabled creates PodMonitor objects instead of deploying the bundled prometheus.
+	// The prometheus-operator managed instance is queried through URL, which is required in this mode
+	Enabled bool `json:"enabled,omitempty"`
+	// Labels are added to the monitoring objects, so that they are selected by the Prometheus instance
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PrometheusPersistence defines the storage of the bundled prometheus
+type PrometheusPersistence struct {
+	// Enabled stores the metrics in a PersistentVolumeClaim instead of an emptyDir. A single replica of
+	// prometheus runs then, since the pods cannot share the ReadWriteOnce claim
+	Enabled bool `json:"enabled,omitempty"`
+	// StorageClassName of the claim, the default storage class is used when empty
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Size of the claim
+	Size *resource.Quantity `json:"size,omitempty"`
+	// RetentionTime is how long the samples are kept (e.g. 6h)
+	RetentionTime string `json:"retentionTime,omitempty"`
+	// RetentionSize is the maximum size of the stored samples (e.g. 5GB)
+	// +kubebuilder:validation:Pattern=`^[0-9]+(B|KB|MB|GB|TB|PB|EB)$`
+	RetentionSize string `json:"retentionSize,omitempty"`
+}
+
+// PrometheusRemoteWrite defines a remote_write endpoint of the bundled prometheus
+type PrometheusRemoteWrite struct {
+	// URL of the endpoint the samples are sent to
+	URL string `json:"url"`
+	// RemoteTimeout is the timeout of the requests to the endpoint (e.g. 30s)
+	RemoteTimeout string `json:"remoteTimeout,omitempty"`
+}
+
+// PrometheusAuthType is the type of credentials used to query Prometheus
+type PrometheusAuthType string
+
+const (
+	// PrometheusAuthBasic reads the username and password keys of the Secret
+	PrometheusAuthBasic PrometheusAuthType = "basic"
+)
+
+// PrometheusCredentials references the Secret holding the credentials of an external Prometheus.
+// The Linkerd components only take the URL of Prometheus, so they only support basic auth
+type PrometheusCredentials struct {
+	// SecretName is the name of the Secret in the Linkerd namespace
+	SecretName string `json:"secretName"`
+	// Type of the credentials stored in the Secret
+	// +kubebuilder:validation:Enum=basic
+	Type PrometheusAuthType `json:"type"`
+}
+
+// MulticlusterConfiguration defines the gateway receiving the traffic of the linked clusters.
+// The clusters to mirror the services of are defined by the Links of its namespace
+type MulticlusterConfiguration struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Namespace holds the gateway and the Links to the other clusters
+	Namespace string `json:"namespace,omitempty"`
+	// Gateway configuration options
+	Gateway GatewayConfiguration `json:"gateway,omitempty"`
+	// ServiceMirror configuration options, applied to the service mirror of every Link
+	ServiceMirror ServiceMirrorConfiguration `json:"serviceMirror,omitempty"`
+	// RemoteMirrorServiceAccountName is the service account the service mirrors of the other clusters use
+	// to read the services of this cluster
+	RemoteMirrorServiceAccountName string `json:"remoteMirrorServiceAccountName,omitempty"`
+}
+
+// GatewayConfiguration defines the k8s spec configuration for the multicluster gateway
+type GatewayConfiguration struct {
+	BaseK8sResourceConfiguration `json:",inline"`
+	// Port receives the traffic of the other clusters
+	Port *int32 `json:"port,omitempty"`
+	// ProbePort serves the health checks of the service mirrors of the other clusters
+	ProbePort *int32 `json:"probePort,omitempty"`
+	ProbePath string `json:"probePath,omitempty"`
+	// ProbeSeconds is how often the service mirrors of the other clusters probe the gateway
+	ProbeSeconds *int32 `json:"probeSeconds,omitempty"`
+	// ServiceType exposes the gateway to the other clusters
+	// +kubebuilder:validation:Enum=LoadBalancer;NodePort;ClusterIP
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+}
+
+// ServiceMirrorConfiguration defines the k8s spec configuration for the service mirrors
+type ServiceMirrorConfiguration struct {
+	BaseK8sResourceConfiguration `json:",inline"`
+	LogLevel                     string `json:"logLevel,omitempty"`
+	// EventRequeueLimit is how many times a failed update of a mirrored service is retried
+	EventRequeueLimit *int32 `json:"eventRequeueLimit,omitempty"`
 }
 
 // ProxyInjectorConfiguration defines the k8s spec configuration for the proxy injector
 type ProxyInjectorConfiguration struct {
 	BaseK8sResourceConfiguration `json:",inline"`
+	// FailurePolicy defines how the API server handles pods when the injector is unavailable.
+	// Defaults to Fail with high availability, so that pods are never created without a proxy
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	FailurePolicy admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+	// NamespaceSelector selects the namespaces whose pods are sent to the injector. The namespaces of
+	// the other revisions and the ones labeled config.linkerd.io/admission-webhooks: disabled, such as the
+	// namespace of the control plane, are always excluded
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ObjectSelector selects the pods sent to the injector
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+	// TimeoutSeconds is how long the API server waits for the injector
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// ReinvocationPolicy defines whether the injector is called again after the other mutating webhooks
+	// +kubebuilder:validation:Enum=Never;IfNeeded
+	ReinvocationPolicy admissionregistrationv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty"`
+}
+
+// SPValidatorConfiguration defines the k8s spec configuration for the service profile validator
+type SPValidatorConfiguration struct {
+	BaseK8sResourceConfiguration `json:",inline"`
+	// FailurePolicy defines how the API server handles ServiceProfiles when the validator is unavailable
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	FailurePolicy admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
 }
 
 // TapConfiguration defines the k8s spec configuration for the linkerd tap
@@ -102,6 +328,64 @@ type WebConfiguration struct {
 	BaseK8sResourceConfiguration `json:",inline"`
 }
 
+// TracingConfiguration defines the configuration of the distributed tracing add-on
+type TracingConfiguration struct {
+	// Enabled configures the control plane to export its spans to the trace collector
+	Enabled bool `json:"enabled,omitempty"`
+	// Collector configuration options
+	Collector CollectorConfiguration `json:"collector,omitempty"`
+	// Jaeger configuration options
+	Jaeger JaegerConfiguration `json:"jaeger,omitempty"`
+}
+
+// CollectorConfiguration defines the k8s spec configuration for the OpenCensus collector
+type CollectorConfiguration struct {
+	BaseK8sResourceConfiguration `json:",inline"`
+	// SamplingPercentage is the percentage of traces sampled by the collector
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercentage *int32 `json:"samplingPercentage,omitempty"`
+	// ExternalAddr is the address (host:port) of an existing OpenCensus collector the spans are exported to.
+	// When set, neither the bundled collector nor Jaeger are deployed
+	ExternalAddr string `json:"externalAddr,omitempty"`
+	// ExternalIdentity is the TLS identity of the external collector, if it is part of the mesh
+	ExternalIdentity string `json:"externalIdentity,omitempty"`
+}
+
+// JaegerConfiguration defines the k8s spec configuration for the Jaeger all-in-one deployment
+type JaegerConfiguration struct {
+	BaseK8sResourceConfiguration `json:",inline"`
+}
+
+// ProxyInitConfiguration defines the configuration of the proxy-init container
+// that sets up the iptables rules redirecting the traffic through the proxy
+type ProxyInitConfiguration struct {
+	// IgnoreInboundPorts is the list of inbound ports or port ranges (e.g. 5432 or 5000-5010) that bypass the proxy
+	IgnoreInboundPorts []string `json:"ignoreInboundPorts,omitempty"`
+	// IgnoreOutboundPorts is the list of outbound ports or port ranges that bypass the proxy
+	IgnoreOutboundPorts []string `json:"ignoreOutboundPorts,omitempty"`
+	// SkipSubnets is the list of CIDRs whose traffic bypasses the proxy
+	SkipSubnets []string `json:"skipSubnets,omitempty"`
+	// IptablesMode selects the iptables backend used to configure the rules, nft requires proxy-init v1.4.0 or later
+	// +kubebuilder:validation:Enum=legacy;nft
+	IptablesMode IptablesMode `json:"iptablesMode,omitempty"`
+}
+
+// CNIConfiguration defines the k8s spec configuration for the linkerd CNI plugin
+type CNIConfiguration struct {
+	BaseK8sResourceConfiguration `json:",inline"`
+	// Enabled installs the linkerd-cni DaemonSet which replaces the proxy-init containers. The plugin is shared
+	// by the control planes, it is installed in the linkerd-cni namespace with the settings of the oldest Linkerd
+	// resource enabling it
+	Enabled bool `json:"enabled,omitempty"`
+	// DestCNINetDir is the directory on the host where the CNI configuration is installed
+	DestCNINetDir string `json:"destCNINetDir,omitempty"`
+	// DestCNIBinDir is the directory on the host where the CNI binaries are installed
+	DestCNIBinDir string `json:"destCNIBinDir,omitempty"`
+	// LogLevel is the log level of the CNI plugin
+	LogLevel string `json:"logLevel,omitempty"`
+}
+
 // SelfSignedCertificates defines the certificates used in the operator.
 // If Issue is true, the operator will self generate the certificates
 type SelfSignedCertificates struct {
@@ -110,6 +394,12 @@ type SelfSignedCertificates struct {
 	CrtPEM          string `json:"crtPEM,omitempty"`
 }
 
+// UpgradeConfiguration defines how the control plane is upgraded when the version changes
+type UpgradeConfiguration struct {
+	// ComponentTimeout is the time a component has to finish rolling out before the upgrade is rolled back
+	ComponentTimeout *metav1.Duration `json:"componentTimeout,omitempty"`
+}
+
 // LinkerdVersion stores the intended Linkerd version
 type LinkerdVersion string
 
@@ -117,6 +407,16 @@ type LinkerdVersion string
 type LinkerdSpec struct {
 	// Contains the intended Linkerd version
 	Version LinkerdVersion `json:"version"`
+	// Revision suffixes the name of every cluster-scoped resource of the control plane, so that several control planes
+	// run side by side. The injector of a revision serves the namespaces labeled linkerd.io/revision=<revision>,
+	// the injector without revision the namespaces without the label. The proxies find their control plane
+	// through its namespace, so each revision is installed in its own namespace: the namespaced resources keep
+	// their name, and the operator only reconciles the oldest Linkerd resource of a namespace
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=20
+	Revision string `json:"revision,omitempty"`
+	// HighAvailability makes the control plane resilient to the loss of a component
+	HighAvailability *bool `json:"highAvailability,omitempty"`
 	// LogLevel is the log level for the linkerd controller
 	LogLevel string `json:"logLevel,omitempty"`
 	// SelfSignedCertificates determines if the user is going to supply the certificates or if the operator needs to generate new ones
@@ -126,18 +426,36 @@ type LinkerdSpec struct {
 	// ImagePullPolicy describes a policy for if/when to pull a container image
 	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ProxyInit configuration options
+	ProxyInit ProxyInitConfiguration `json:"proxyInit,omitempty"`
+	// CNI configuration options
+	CNI CNIConfiguration `json:"cni,omitempty"`
 	// Controller configuration options
 	Controller ControllerConfiguration `json:"controller,omitempty"`
+	// DataPlane configuration options
+	DataPlane DataPlaneConfiguration `json:"dataPlane,omitempty"`
 	// Destination configuration options
 	Destination DestinationConfiguration `json:"destination,omitempty"`
+	// Grafana configuration options
+	Grafana GrafanaConfiguration `json:"grafana,omitempty"`
+	// Heartbeat configuration options
+	Heartbeat HeartbeatConfiguration `json:"heartbeat,omitempty"`
 	// Identity configuration options
 	Identity IdentityConfiguration `json:"identity,omitempty"`
+	// Multicluster configuration options
+	Multicluster MulticlusterConfiguration `json:"multicluster,omitempty"`
 	// Prometheus configuration options
 	Prometheus PrometheusConfiguration `json:"prometheus,omitempty"`
 	// ProxyInjector configuration options
 	ProxyInjector ProxyInjectorConfiguration `json:"proxyInjector,omitempty"`
+	// SPValidator configuration options
+	SPValidator SPValidatorConfiguration `json:"spValidator,omitempty"`
 	// Tap configuration options
 	Tap TapConfiguration `json:"tap,omitempty"`
+	// Tracing configuration options
+	Tracing TracingConfiguration `json:"tracing,omitempty"`
+	// Upgrade configuration options
+	Upgrade UpgradeConfiguration `json:"upgrade,omitempty"`
 	// Web configuration options
 	Web WebConfiguration `json:"web,omitempty"`
 }
@@ -146,18 +464,116 @@ type LinkerdSpec struct {
 type LinkerdStatus struct {
 	Status       ConfigState `json:"Status,omitempty"`
 	ErrorMessage string      `json:"ErrorMessage,omitempty"`
+	// Conditions are the latest observations of the Linkerd resource
+	Conditions []LinkerdCondition `json:"conditions,omitempty"`
+	// Version is the Linkerd version every component runs
+	Version LinkerdVersion `json:"version,omitempty"`
+	// Upgrade records the progress of the latest upgrade
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+	// DataPlane records the progress of the restarts of the workloads
+	DataPlane *DataPlaneStatus `json:"dataPlane,omitempty"`
+	// Multicluster records the state of the gateway
+	Multicluster *GatewayStatus `json:"multicluster,omitempty"`
+}
+
+// GatewayStatus records the state of a multicluster gateway
+type GatewayStatus struct {
+	// Alive is true when the gateway service has ready endpoints
+	Alive bool `json:"alive"`
+	// Addresses are the addresses the other clusters reach the gateway at
+	Addresses []string `json:"addresses,omitempty"`
+	Message   string   `json:"message,omitempty"`
+}
+
+// DataPlaneStatus records the progress of the restarts of the workloads
+type DataPlaneStatus struct {
+	// PendingWorkloads is the number of workloads running an outdated proxy or no proxy
+	PendingWorkloads int32 `json:"pendingWorkloads"`
+	// RestartingWorkloads are the workloads rolling out, as kind/namespace/name
+	RestartingWorkloads []string `json:"restartingWorkloads,omitempty"`
+	// LastRestartTime is when the latest batch was restarted
+	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
+	Message         string       `json:"message,omitempty"`
+}
+
+// UpgradeStatus records the progress of an upgrade of the control plane
+type UpgradeStatus struct {
+	FromVersion LinkerdVersion `json:"fromVersion"`
+	ToVersion   LinkerdVersion `json:"toVersion"`
+	Phase       UpgradePhase   `json:"phase"`
+	// UpgradedComponents are the components running the new version, in upgrade order
+	UpgradedComponents []string `json:"upgradedComponents,omitempty"`
+	// CurrentComponent is the component rolling out the new version
+	CurrentComponent string `json:"currentComponent,omitempty"`
+	// CurrentComponentStartTime is when the rollout of the current component started
+	CurrentComponentStartTime *metav1.Time `json:"currentComponentStartTime,omitempty"`
+	// RolledBackGeneration is the generation of the spec that was rolled back, the upgrade is attempted again
+	// once the spec changes
+	RolledBackGeneration int64  `json:"rolledBackGeneration,omitempty"`
+	Message              string `json:"message,omitempty"`
+}
+
+// LinkerdCondition describes an observation of the Linkerd resource
+type LinkerdCondition struct {
+	Type   ConditionType          `json:"type"`
+	Status corev1.ConditionStatus `json:"status"`
+	// Reason is a CamelCase reason of the last transition
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable explanation of the last transition
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// SetCondition adds or updates the condition of the same type. The transition time
+// is only updated when the status changes
+func (s *LinkerdStatus) SetCondition(condition LinkerdCondition) {
+	s.Conditions = setCondition(s.Conditions, condition)
+}
+
+func setCondition(conditions []LinkerdCondition, condition LinkerdCondition) []LinkerdCondition {
+	for i, c := range conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+		if c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		} else if condition.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = metav1.Now()
+		}
+		conditions[i] = condition
+		return conditions
+	}
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	return append(conditions, condition)
+}
+
+// IsHighlyAvailable returns whether the control plane runs in high availability mode
+func (s LinkerdSpec) IsHighlyAvailable() bool {
+	return util.PointerToBool(s.HighAvailability)
+}
+
+// IsDeployed returns whether the operator deploys the bundled grafana
+func (c GrafanaConfiguration) IsDeployed() bool {
+	return util.PointerToBool(c.Enabled) && c.ExternalAddr == ""
+}
+
+// IsDeployed returns whether the operator deploys the bundled collector and Jaeger
+func (c TracingConfiguration) IsDeployed() bool {
+	return c.Enabled && c.Collector.ExternalAddr == ""
 }
 
-// IsSupported checks if the version of Linkerd is complied with the supported one by the operator
-func (v LinkerdVersion) IsSupported() bool {
-	re, _ := regexp.Compile(supportedLinkerdMinorVersionRegex)
-	return re.Match([]byte(v))
+// IsDeployed returns whether the operator deploys the bundled prometheus
+func (c PrometheusConfiguration) IsDeployed() bool {
+	return c.URL == "" && !c.Operator.Enabled
 }
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // Linkerd is the Schema for the linkerds API
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.Status",description="Status of the resource"
 // +kubebuilder:printcolumn:name="Error",type="string",JSONPath=".status.ErrorMessage",description="Error message"
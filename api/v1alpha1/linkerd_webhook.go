@@ -0,0 +1,277 @@
+/*
+Copyright 2020 The Linkerd2 Operator authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/certs"
+)
+
+// redacted replaces the certificates and keys in the field errors
+const redacted = "<redacted>"
+
+var linkerdlog = logf.Log.WithName("linkerd-resource")
+
+// releases is the ConfigMap of the operator extending the catalog, loaded before a version is looked up
+// since the webhooks may serve a version before the controller reconciled any Linkerd resource
+var releases struct {
+	reader    client.Reader
+	configMap types.NamespacedName
+}
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks of Linkerd. The versions are
+// looked up in the catalog extended by the releasesConfigMap
+func (r *Linkerd) SetupWebhookWithManager(mgr ctrl.Manager, releasesConfigMap types.NamespacedName) error {
+	releases.reader = mgr.GetAPIReader()
+	releases.configMap = releasesConfigMap
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// lookupRelease returns the release of the version from the catalog extended by the releases ConfigMap
+func lookupRelease(version LinkerdVersion) (catalog.Release, bool) {
+	if releases.reader != nil && releases.configMap.Name != "" {
+		if err := catalog.Load(releases.reader, releases.configMap); err != nil {
+			// keep going with the releases known so far
+			linkerdlog.Error(err, "could not extend the release catalog", "configmap", releases.configMap)
+		}
+	}
+	return catalog.Lookup(string(version))
+}
+
+// +kubebuilder:webhook:path=/mutate-linkerd-linkerd-io-v1alpha1-linkerd,mutating=true,failurePolicy=fail,groups=linkerd.linkerd.io,resources=linkerds,verbs=create;update,versions=v1alpha1,name=mlinkerd.kb.io
+
+var _ webhook.Defaulter = &Linkerd{}
+
+// Default persists the defaults of the intended version. The images of the release are left out, so that
+// changing the version upgrades them
+func (r *Linkerd) Default() {
+	release, ok := lookupRelease(r.Spec.Version)
+	if !ok {
+		// rejected by the validation
+		return
+	}
+	linkerdlog.Info("default", "namespace", r.Namespace, "name", r.Name)
+
+	defaulted := r.DeepCopy()
+	SetDefaults(defaulted, release)
+	images := releaseImages(&r.Spec)
+	for i, image := range releaseImages(&defaulted.Spec) {
+		*image = *images[i]
+	}
+	r.Spec = defaulted.Spec
+}
+
+// releaseImages returns the images defaulted from the release of the version
+func releaseImages(spec *LinkerdSpec) []**string {
+	return []**string{
+		&spec.CNI.Image,
+		&spec.Controller.Image,
+		&spec.Destination.Image,
+		&spec.Grafana.Image,
+		&spec.Heartbeat.Image,
+		&spec.Identity.Image,
+		&spec.Multicluster.ServiceMirror.Image,
+		&spec.ProxyInjector.Image,
+		&spec.SPValidator.Image,
+		&spec.Tap.Image,
+		&spec.Web.Image,
+	}
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-linkerd-linkerd-io-v1alpha1-linkerd,mutating=false,failurePolicy=fail,groups=linkerd.linkerd.io,resources=linkerds,versions=v1alpha1,name=vlinkerd.kb.io
+
+var _ webhook.Validator = &Linkerd{}
+
+// ValidateCreate rejects the Linkerd resources the operator cannot reconcile
+func (r *Linkerd) ValidateCreate() error {
+	return r.invalid(r.validateSpec())
+}
+
+// ValidateUpdate rejects the invalid updates, the revision cannot change since it names every resource
+func (r *Linkerd) ValidateUpdate(old runtime.Object) error {
+	errs := r.validateSpec()
+	if previous, ok := old.(*Linkerd); ok && previous.Spec.Revision != r.Spec.Revision {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "revision"), "the revision cannot change, install another control plane instead"))
+	}
+	return r.invalid(errs)
+}
+
+// ValidateDelete accepts every deletion
+func (r *Linkerd) ValidateDelete() error {
+	return nil
+}
+
+func (r *Linkerd) invalid(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("Linkerd").GroupKind(), r.Name, errs)
+}
+
+// validateSpec checks the fields the CRD schema cannot
+func (r *Linkerd) validateSpec() field.ErrorList {
+	spec := field.NewPath("spec")
+	var errs field.ErrorList
+
+	release, ok := lookupRelease(r.Spec.Version)
+	if !ok {
+		errs = append(errs, field.NotSupported(spec.Child("version"), r.Spec.Version, catalog.Versions()))
+	}
+
+	switch r.Spec.ImagePullPolicy {
+	case "", corev1.PullAlways, corev1.PullNever, corev1.PullIfNotPresent:
+	default:
+		errs = append(errs, field.NotSupported(spec.Child("imagePullPolicy"), r.Spec.ImagePullPolicy,
+			[]string{string(corev1.PullAlways), string(corev1.PullNever), string(corev1.PullIfNotPresent)}))
+	}
+
+	for _, component := range []struct {
+		path   *field.Path
+		config BaseK8sResourceConfiguration
+	}{
+		{spec.Child("cni"), r.Spec.CNI.BaseK8sResourceConfiguration},
+		{spec.Child("controller"), r.Spec.Controller.BaseK8sResourceConfiguration},
+		{spec.Child("destination"), r.Spec.Destination.BaseK8sResourceConfiguration},
+		{spec.Child("grafana"), r.Spec.Grafana.BaseK8sResourceConfiguration},
+		{spec.Child("heartbeat"), r.Spec.Heartbeat.BaseK8sResourceConfiguration},
+		{spec.Child("identity"), r.Spec.Identity.BaseK8sResourceConfiguration},
+		{spec.Child("multicluster", "gateway"), r.Spec.Multicluster.Gateway.BaseK8sResourceConfiguration},
+		{spec.Child("multicluster", "serviceMirror"), r.Spec.Multicluster.ServiceMirror.BaseK8sResourceConfiguration},
+		{spec.Child("prometheus"), r.Spec.Prometheus.BaseK8sResourceConfiguration},
+		{spec.Child("proxyInjector"), r.Spec.ProxyInjector.BaseK8sResourceConfiguration},
+		{spec.Child("spValidator"), r.Spec.SPValidator.BaseK8sResourceConfiguration},
+		{spec.Child("tap"), r.Spec.Tap.BaseK8sResourceConfiguration},
+		{spec.Child("tracing", "collector"), r.Spec.Tracing.Collector.BaseK8sResourceConfiguration},
+		{spec.Child("tracing", "jaeger"), r.Spec.Tracing.Jaeger.BaseK8sResourceConfiguration},
+		{spec.Child("web"), r.Spec.Web.BaseK8sResourceConfiguration},
+	} {
+		if replicas := component.config.ReplicaCount; replicas != nil && *replicas < 0 {
+			errs = append(errs, field.Invalid(component.path.Child("replicaCount"), *replicas, "must be greater than or equal to 0"))
+		}
+	}
+
+	if prometheusURL := r.Spec.Prometheus.URL; prometheusURL != "" {
+		if u, err := url.Parse(prometheusURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, field.Invalid(spec.Child("prometheus", "url"), prometheusURL, "must be an absolute URL, e.g. http://prometheus.monitoring:9090"))
+		} else if u.User != nil {
+			errs = append(errs, field.Invalid(spec.Child("prometheus", "url"), redacted, "the credentials must be set through spec.prometheus.credentials"))
+		}
+	}
+
+	errs = append(errs, validateProxyInit(spec.Child("proxyInit"), r.Spec.ProxyInit)...)
+	if ok && r.Spec.ProxyInit.IptablesMode == IptablesModeNft && !release.SupportsIptablesNft() {
+		errs = append(errs, field.Invalid(spec.Child("proxyInit", "iptablesMode"), r.Spec.ProxyInit.IptablesMode,
+			fmt.Sprintf("proxy-init %s of %s does not support the nft mode", release.ProxyInitVersion, release.Version)))
+	}
+
+	errs = append(errs, validateCertificates(spec.Child("selfSignedCerts"), r.Spec.SelfSignedCertificates)...)
+
+	issuer := r.Spec.Identity.Issuer
+	if issuer.SigningSecret != "" && issuer.ExternalSecret != "" {
+		errs = append(errs, field.Forbidden(spec.Child("identity", "issuer", "externalSecret"), "only one of signingSecret and externalSecret can be set"))
+	}
+	if trustAnchors := r.Spec.Identity.TrustAnchors; trustAnchors != nil && (trustAnchors.Secret == nil) == (trustAnchors.ConfigMap == nil) {
+		errs = append(errs, field.Invalid(spec.Child("identity", "trustAnchors"), "", "exactly one of secret and configMap must be set"))
+	}
+
+	return errs
+}
+
+// validateProxyInit checks the ports, port ranges and subnets given to proxy-init
+func validateProxyInit(path *field.Path, c ProxyInitConfiguration) field.ErrorList {
+	var errs field.ErrorList
+	for _, ports := range []struct {
+		path   *field.Path
+		ranges []string
+	}{
+		{path.Child("ignoreInboundPorts"), c.IgnoreInboundPorts},
+		{path.Child("ignoreOutboundPorts"), c.IgnoreOutboundPorts},
+	} {
+		for i, r := range ports.ranges {
+			if err := validatePortRange(r); err != nil {
+				errs = append(errs, field.Invalid(ports.path.Index(i), r, err.Error()))
+			}
+		}
+	}
+	for i, subnet := range c.SkipSubnets {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			errs = append(errs, field.Invalid(path.Child("skipSubnets").Index(i), subnet, "must be a CIDR, e.g. 10.0.0.0/8"))
+		}
+	}
+	return errs
+}
+
+// validatePortRange checks a port or an inclusive range of ports such as 5000-5010
+func validatePortRange(r string) error {
+	bounds := strings.SplitN(r, "-", 2)
+	ports := make([]int, 0, len(bounds))
+	for _, bound := range bounds {
+		port, err := strconv.Atoi(bound)
+		if err != nil || port < 1 || port > 65535 {
+			return errors.New("must be a port between 1 and 65535 or a range of ports, e.g. 5000-5010")
+		}
+		ports = append(ports, port)
+	}
+	if len(ports) == 2 && ports[0] > ports[1] {
+		return errors.New("the first port of the range must not be greater than the last one")
+	}
+	return nil
+}
+
+// validateCertificates checks that the certificates are PEM encoded and that the issuer chains to the trust anchors
+func validateCertificates(path *field.Path, c *SelfSignedCertificates) field.ErrorList {
+	if c == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if _, err := certs.DecodePEMCertPool(c.TrustAnchorsPEM); err != nil {
+		errs = append(errs, field.Invalid(path.Child("trustAnchorsPEM"), redacted, err.Error()))
+	}
+	if _, err := certs.DecodePEMCrt(c.CrtPEM); err != nil {
+		errs = append(errs, field.Invalid(path.Child("crtPEM"), redacted, err.Error()))
+	}
+	if _, err := certs.DecodePEMKey(c.KeyPEM); err != nil {
+		errs = append(errs, field.Invalid(path.Child("keyPEM"), redacted, err.Error()))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if err := certs.VerifyIssuerCertificate(c.TrustAnchorsPEM, c.CrtPEM, c.KeyPEM, time.Now()); err != nil {
+		errs = append(errs, field.Invalid(path.Child("crtPEM"), redacted, err.Error()))
+	}
+	return errs
+}
@@ -22,7 +22,8 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -86,6 +87,43 @@ func (in *BaseK8sResourceConfiguration) DeepCopy() *BaseK8sResourceConfiguration
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNIConfiguration) DeepCopyInto(out *CNIConfiguration) {
+	*out = *in
+	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIConfiguration.
+func (in *CNIConfiguration) DeepCopy() *CNIConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CNIConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorConfiguration) DeepCopyInto(out *CollectorConfiguration) {
+	*out = *in
+	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorConfiguration.
+func (in *CollectorConfiguration) DeepCopy() *CollectorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
@@ -102,6 +140,65 @@ func (in *ControllerConfiguration) DeepCopy() *ControllerConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneConfiguration) DeepCopyInto(out *DataPlaneConfiguration) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchInterval != nil {
+		in, out := &in.BatchInterval, &out.BatchInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneConfiguration.
+func (in *DataPlaneConfiguration) DeepCopy() *DataPlaneConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneStatus) DeepCopyInto(out *DataPlaneStatus) {
+	*out = *in
+	if in.RestartingWorkloads != nil {
+		in, out := &in.RestartingWorkloads, &out.RestartingWorkloads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRestartTime != nil {
+		in, out := &in.LastRestartTime, &out.LastRestartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneStatus.
+func (in *DataPlaneStatus) DeepCopy() *DataPlaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DestinationConfiguration) DeepCopyInto(out *DestinationConfiguration) {
 	*out = *in
@@ -118,10 +215,109 @@ func (in *DestinationConfiguration) DeepCopy() *DestinationConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayConfiguration) DeepCopyInto(out *GatewayConfiguration) {
+	*out = *in
+	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProbePort != nil {
+		in, out := &in.ProbePort, &out.ProbePort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProbeSeconds != nil {
+		in, out := &in.ProbeSeconds, &out.ProbeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfiguration.
+func (in *GatewayConfiguration) DeepCopy() *GatewayConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayStatus) DeepCopyInto(out *GatewayStatus) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayStatus.
+func (in *GatewayStatus) DeepCopy() *GatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaConfiguration) DeepCopyInto(out *GrafanaConfiguration) {
+	*out = *in
+	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaConfiguration.
+func (in *GrafanaConfiguration) DeepCopy() *GrafanaConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeartbeatConfiguration) DeepCopyInto(out *HeartbeatConfiguration) {
+	*out = *in
+	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeartbeatConfiguration.
+func (in *HeartbeatConfiguration) DeepCopy() *HeartbeatConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IdentityConfiguration) DeepCopyInto(out *IdentityConfiguration) {
 	*out = *in
 	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+	if in.TrustAnchors != nil {
+		in, out := &in.TrustAnchors, &out.TrustAnchors
+		*out = new(TrustAnchorsReference)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Issuer = in.Issuer
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityConfiguration.
@@ -134,13 +330,149 @@ func (in *IdentityConfiguration) DeepCopy() *IdentityConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerConfiguration) DeepCopyInto(out *IssuerConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerConfiguration.
+func (in *IssuerConfiguration) DeepCopy() *IssuerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JaegerConfiguration) DeepCopyInto(out *JaegerConfiguration) {
+	*out = *in
+	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JaegerConfiguration.
+func (in *JaegerConfiguration) DeepCopy() *JaegerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(JaegerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Link) DeepCopyInto(out *Link) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Link.
+func (in *Link) DeepCopy() *Link {
+	if in == nil {
+		return nil
+	}
+	out := new(Link)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Link) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkList) DeepCopyInto(out *LinkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Link, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkList.
+func (in *LinkList) DeepCopy() *LinkList {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LinkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkSpec) DeepCopyInto(out *LinkSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkSpec.
+func (in *LinkSpec) DeepCopy() *LinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkStatus) DeepCopyInto(out *LinkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]LinkerdCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkStatus.
+func (in *LinkStatus) DeepCopy() *LinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Linkerd) DeepCopyInto(out *Linkerd) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Linkerd.
@@ -161,6 +493,22 @@ func (in *Linkerd) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkerdCondition) DeepCopyInto(out *LinkerdCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdCondition.
+func (in *LinkerdCondition) DeepCopy() *LinkerdCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkerdCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkerdList) DeepCopyInto(out *LinkerdList) {
 	*out = *in
@@ -196,6 +544,11 @@ func (in *LinkerdList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkerdSpec) DeepCopyInto(out *LinkerdSpec) {
 	*out = *in
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(bool)
+		**out = **in
+	}
 	if in.SelfSignedCertificates != nil {
 		in, out := &in.SelfSignedCertificates, &out.SelfSignedCertificates
 		*out = new(SelfSignedCertificates)
@@ -206,12 +559,21 @@ func (in *LinkerdSpec) DeepCopyInto(out *LinkerdSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ProxyInit.DeepCopyInto(&out.ProxyInit)
+	in.CNI.DeepCopyInto(&out.CNI)
 	in.Controller.DeepCopyInto(&out.Controller)
+	in.DataPlane.DeepCopyInto(&out.DataPlane)
 	in.Destination.DeepCopyInto(&out.Destination)
+	in.Grafana.DeepCopyInto(&out.Grafana)
+	in.Heartbeat.DeepCopyInto(&out.Heartbeat)
 	in.Identity.DeepCopyInto(&out.Identity)
+	in.Multicluster.DeepCopyInto(&out.Multicluster)
 	in.Prometheus.DeepCopyInto(&out.Prometheus)
 	in.ProxyInjector.DeepCopyInto(&out.ProxyInjector)
+	in.SPValidator.DeepCopyInto(&out.SPValidator)
 	in.Tap.DeepCopyInto(&out.Tap)
+	in.Tracing.DeepCopyInto(&out.Tracing)
+	in.Upgrade.DeepCopyInto(&out.Upgrade)
 	in.Web.DeepCopyInto(&out.Web)
 }
 
@@ -228,6 +590,28 @@ func (in *LinkerdSpec) DeepCopy() *LinkerdSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LinkerdStatus) DeepCopyInto(out *LinkerdStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]LinkerdCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataPlane != nil {
+		in, out := &in.DataPlane, &out.DataPlane
+		*out = new(DataPlaneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Multicluster != nil {
+		in, out := &in.Multicluster, &out.Multicluster
+		*out = new(GatewayStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdStatus.
@@ -240,10 +624,100 @@ func (in *LinkerdStatus) DeepCopy() *LinkerdStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MulticlusterConfiguration) DeepCopyInto(out *MulticlusterConfiguration) {
+	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.ServiceMirror.DeepCopyInto(&out.ServiceMirror)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MulticlusterConfiguration.
+func (in *MulticlusterConfiguration) DeepCopy() *MulticlusterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MulticlusterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAlertsConfiguration) DeepCopyInto(out *PrometheusAlertsConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProxyErrorRatePercentage != nil {
+		in, out := &in.ProxyErrorRatePercentage, &out.ProxyErrorRatePercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAlertsConfiguration.
+func (in *PrometheusAlertsConfiguration) DeepCopy() *PrometheusAlertsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAlertsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusConfiguration) DeepCopyInto(out *PrometheusConfiguration) {
 	*out = *in
 	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(PrometheusCredentials)
+		**out = **in
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]PrometheusRemoteWrite, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuleFiles != nil {
+		in, out := &in.RuleFiles, &out.RuleFiles
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	in.Operator.DeepCopyInto(&out.Operator)
+	in.Alerts.DeepCopyInto(&out.Alerts)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusConfiguration.
@@ -256,10 +730,132 @@ func (in *PrometheusConfiguration) DeepCopy() *PrometheusConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusCredentials) DeepCopyInto(out *PrometheusCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusCredentials.
+func (in *PrometheusCredentials) DeepCopy() *PrometheusCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusOperatorConfiguration) DeepCopyInto(out *PrometheusOperatorConfiguration) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusOperatorConfiguration.
+func (in *PrometheusOperatorConfiguration) DeepCopy() *PrometheusOperatorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusOperatorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusPersistence) DeepCopyInto(out *PrometheusPersistence) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusPersistence.
+func (in *PrometheusPersistence) DeepCopy() *PrometheusPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRemoteWrite) DeepCopyInto(out *PrometheusRemoteWrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRemoteWrite.
+func (in *PrometheusRemoteWrite) DeepCopy() *PrometheusRemoteWrite {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRemoteWrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyInitConfiguration) DeepCopyInto(out *ProxyInitConfiguration) {
+	*out = *in
+	if in.IgnoreInboundPorts != nil {
+		in, out := &in.IgnoreInboundPorts, &out.IgnoreInboundPorts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreOutboundPorts != nil {
+		in, out := &in.IgnoreOutboundPorts, &out.IgnoreOutboundPorts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipSubnets != nil {
+		in, out := &in.SkipSubnets, &out.SkipSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyInitConfiguration.
+func (in *ProxyInitConfiguration) DeepCopy() *ProxyInitConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyInitConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyInjectorConfiguration) DeepCopyInto(out *ProxyInjectorConfiguration) {
 	*out = *in
 	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyInjectorConfiguration.
@@ -272,6 +868,22 @@ func (in *ProxyInjectorConfiguration) DeepCopy() *ProxyInjectorConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPValidatorConfiguration) DeepCopyInto(out *SPValidatorConfiguration) {
+	*out = *in
+	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPValidatorConfiguration.
+func (in *SPValidatorConfiguration) DeepCopy() *SPValidatorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SPValidatorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelfSignedCertificates) DeepCopyInto(out *SelfSignedCertificates) {
 	*out = *in
@@ -287,6 +899,27 @@ func (in *SelfSignedCertificates) DeepCopy() *SelfSignedCertificates {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMirrorConfiguration) DeepCopyInto(out *ServiceMirrorConfiguration) {
+	*out = *in
+	in.BaseK8sResourceConfiguration.DeepCopyInto(&out.BaseK8sResourceConfiguration)
+	if in.EventRequeueLimit != nil {
+		in, out := &in.EventRequeueLimit, &out.EventRequeueLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMirrorConfiguration.
+func (in *ServiceMirrorConfiguration) DeepCopy() *ServiceMirrorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMirrorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServicePort) DeepCopyInto(out *ServicePort) {
 	*out = *in
@@ -345,6 +978,92 @@ func (in *TapConfiguration) DeepCopy() *TapConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfiguration) DeepCopyInto(out *TracingConfiguration) {
+	*out = *in
+	in.Collector.DeepCopyInto(&out.Collector)
+	in.Jaeger.DeepCopyInto(&out.Jaeger)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfiguration.
+func (in *TracingConfiguration) DeepCopy() *TracingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustAnchorsReference) DeepCopyInto(out *TrustAnchorsReference) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustAnchorsReference.
+func (in *TrustAnchorsReference) DeepCopy() *TrustAnchorsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustAnchorsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeConfiguration) DeepCopyInto(out *UpgradeConfiguration) {
+	*out = *in
+	if in.ComponentTimeout != nil {
+		in, out := &in.ComponentTimeout, &out.ComponentTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeConfiguration.
+func (in *UpgradeConfiguration) DeepCopy() *UpgradeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.UpgradedComponents != nil {
+		in, out := &in.UpgradedComponents, &out.UpgradedComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentComponentStartTime != nil {
+		in, out := &in.CurrentComponentStartTime, &out.CurrentComponentStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebConfiguration) DeepCopyInto(out *WebConfiguration) {
 	*out = *in
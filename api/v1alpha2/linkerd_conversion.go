@@ -0,0 +1,336 @@
+/*
+Copyright 2020 The Linkerd2 Operator authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/goph/emperror"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+// CertificatesAnnotation holds the v1alpha1 self signed certificates, which have no field in v1alpha2.
+// It is only kept so that converting back to v1alpha1 loses nothing, until the operator moves the
+// certificates to the Secret referenced by spec.identity.certificatesSecret
+const CertificatesAnnotation = "linkerd.linkerd.io/v1alpha1-self-signed-certs"
+
+var _ conversion.Convertible = &Linkerd{}
+
+// ConvertTo converts the Linkerd resource to the v1alpha1 hub
+func (src *Linkerd) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Linkerd)
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = v1alpha1.LinkerdSpec{
+		Version:                 v1alpha1.LinkerdVersion(in.Spec.Version),
+		Revision:                in.Spec.Revision,
+		HighAvailability:        in.Spec.HighAvailability,
+		LogLevel:                in.Spec.LogLevel,
+		ImagePullPolicy:         in.Spec.ImagePullPolicy,
+		AutoInjectionNamespaces: in.Spec.Proxy.AutoInjectionNamespaces,
+		ProxyInit: v1alpha1.ProxyInitConfiguration{
+			IgnoreInboundPorts:  in.Spec.Networking.ProxyInit.IgnoreInboundPorts,
+			IgnoreOutboundPorts: in.Spec.Networking.ProxyInit.IgnoreOutboundPorts,
+			SkipSubnets:         in.Spec.Networking.ProxyInit.SkipSubnets,
+			IptablesMode:        v1alpha1.IptablesMode(in.Spec.Networking.ProxyInit.IptablesMode),
+		},
+		CNI: v1alpha1.CNIConfiguration{
+			Enabled:       in.Spec.Networking.CNI.Enabled,
+			DestCNINetDir: in.Spec.Networking.CNI.DestCNINetDir,
+			DestCNIBinDir: in.Spec.Networking.CNI.DestCNIBinDir,
+			LogLevel:      in.Spec.Networking.CNI.LogLevel,
+		},
+		DataPlane: v1alpha1.DataPlaneConfiguration{
+			AutoRestart:   in.Spec.Proxy.Restarts.AutoRestart,
+			BatchSize:     in.Spec.Proxy.Restarts.BatchSize,
+			BatchInterval: in.Spec.Proxy.Restarts.BatchInterval,
+			MaxConcurrent: in.Spec.Proxy.Restarts.MaxConcurrent,
+		},
+		Grafana: v1alpha1.GrafanaConfiguration{
+			Enabled:      in.Spec.AddOns.Grafana.Enabled,
+			ExternalAddr: in.Spec.AddOns.Grafana.ExternalAddr,
+		},
+		Heartbeat: v1alpha1.HeartbeatConfiguration{
+			Enabled:  in.Spec.AddOns.Heartbeat.Enabled,
+			Schedule: in.Spec.AddOns.Heartbeat.Schedule,
+			Endpoint: in.Spec.AddOns.Heartbeat.Endpoint,
+		},
+		Identity: v1alpha1.IdentityConfiguration{
+			Issuer:             v1alpha1.IssuerConfiguration(in.Spec.Identity.Issuer),
+			CertificatesSecret: in.Spec.Identity.CertificatesSecret,
+		},
+		Multicluster: v1alpha1.MulticlusterConfiguration{
+			Enabled:   in.Spec.Networking.Multicluster.Enabled,
+			Namespace: in.Spec.Networking.Multicluster.Namespace,
+			Gateway: v1alpha1.GatewayConfiguration{
+				Port:         in.Spec.Networking.Multicluster.Gateway.Port,
+				ProbePort:    in.Spec.Networking.Multicluster.Gateway.ProbePort,
+				ProbePath:    in.Spec.Networking.Multicluster.Gateway.ProbePath,
+				ProbeSeconds: in.Spec.Networking.Multicluster.Gateway.ProbeSeconds,
+				ServiceType:  in.Spec.Networking.Multicluster.Gateway.ServiceType,
+			},
+			ServiceMirror: v1alpha1.ServiceMirrorConfiguration{
+				LogLevel:          in.Spec.Networking.Multicluster.ServiceMirror.LogLevel,
+				EventRequeueLimit: in.Spec.Networking.Multicluster.ServiceMirror.EventRequeueLimit,
+			},
+			RemoteMirrorServiceAccountName: in.Spec.Networking.Multicluster.RemoteMirrorServiceAccountName,
+		},
+		ProxyInjector: v1alpha1.ProxyInjectorConfiguration{
+			FailurePolicy:      in.Spec.Admission.ProxyInjector.FailurePolicy,
+			NamespaceSelector:  in.Spec.Admission.ProxyInjector.NamespaceSelector,
+			ObjectSelector:     in.Spec.Admission.ProxyInjector.ObjectSelector,
+			TimeoutSeconds:     in.Spec.Admission.ProxyInjector.TimeoutSeconds,
+			ReinvocationPolicy: in.Spec.Admission.ProxyInjector.ReinvocationPolicy,
+		},
+		SPValidator: v1alpha1.SPValidatorConfiguration{
+			FailurePolicy: in.Spec.Admission.SPValidator.FailurePolicy,
+		},
+		Tracing: v1alpha1.TracingConfiguration{
+			Enabled: in.Spec.AddOns.Tracing.Enabled,
+			Collector: v1alpha1.CollectorConfiguration{
+				SamplingPercentage: in.Spec.AddOns.Tracing.SamplingPercentage,
+				ExternalAddr:       in.Spec.AddOns.Tracing.ExternalAddr,
+				ExternalIdentity:   in.Spec.AddOns.Tracing.ExternalIdentity,
+			},
+		},
+		Upgrade: v1alpha1.UpgradeConfiguration(in.Spec.Upgrade),
+	}
+	if anchors := in.Spec.Identity.TrustAnchors; anchors != nil {
+		dst.Spec.Identity.TrustAnchors = &v1alpha1.TrustAnchorsReference{Secret: anchors.Secret, ConfigMap: anchors.ConfigMap}
+	}
+	if window := in.Spec.Proxy.Restarts.MaintenanceWindow; window != nil {
+		dst.Spec.DataPlane.MaintenanceWindow = &v1alpha1.MaintenanceWindow{Start: window.Start, Duration: window.Duration}
+		for _, day := range window.Days {
+			dst.Spec.DataPlane.MaintenanceWindow.Days = append(dst.Spec.DataPlane.MaintenanceWindow.Days, v1alpha1.Weekday(day))
+		}
+	}
+	convertPrometheusTo(&in.Spec.AddOns.Prometheus, &dst.Spec.Prometheus)
+
+	for name, base := range components(&dst.Spec) {
+		if c, ok := in.Spec.Components[name]; ok {
+			*base = v1alpha1.BaseK8sResourceConfiguration(c)
+		}
+	}
+
+	if raw, ok := dst.Annotations[CertificatesAnnotation]; ok {
+		dst.Spec.SelfSignedCertificates = &v1alpha1.SelfSignedCertificates{}
+		if err := json.Unmarshal([]byte(raw), dst.Spec.SelfSignedCertificates); err != nil {
+			return emperror.Wrap(err, "could not decode the self signed certificates annotation")
+		}
+		delete(dst.Annotations, CertificatesAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	return convertJSON(&in.Status, &dst.Status)
+}
+
+// ConvertFrom converts the v1alpha1 hub to this version
+func (dst *Linkerd) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Linkerd)
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = LinkerdSpec{
+		Version:          string(in.Spec.Version),
+		Revision:         in.Spec.Revision,
+		HighAvailability: in.Spec.HighAvailability,
+		LogLevel:         in.Spec.LogLevel,
+		ImagePullPolicy:  in.Spec.ImagePullPolicy,
+		Identity: IdentityConfiguration{
+			Issuer:             IssuerConfiguration(in.Spec.Identity.Issuer),
+			CertificatesSecret: in.Spec.Identity.CertificatesSecret,
+		},
+		Proxy: ProxyConfiguration{
+			AutoInjectionNamespaces: in.Spec.AutoInjectionNamespaces,
+			Restarts: RestartsConfiguration{
+				AutoRestart:   in.Spec.DataPlane.AutoRestart,
+				BatchSize:     in.Spec.DataPlane.BatchSize,
+				BatchInterval: in.Spec.DataPlane.BatchInterval,
+				MaxConcurrent: in.Spec.DataPlane.MaxConcurrent,
+			},
+		},
+		Networking: NetworkingConfiguration{
+			ProxyInit: ProxyInitConfiguration{
+				IgnoreInboundPorts:  in.Spec.ProxyInit.IgnoreInboundPorts,
+				IgnoreOutboundPorts: in.Spec.ProxyInit.IgnoreOutboundPorts,
+				SkipSubnets:         in.Spec.ProxyInit.SkipSubnets,
+				IptablesMode:        string(in.Spec.ProxyInit.IptablesMode),
+			},
+			CNI: CNIConfiguration{
+				Enabled:       in.Spec.CNI.Enabled,
+				DestCNINetDir: in.Spec.CNI.DestCNINetDir,
+				DestCNIBinDir: in.Spec.CNI.DestCNIBinDir,
+				LogLevel:      in.Spec.CNI.LogLevel,
+			},
+			Multicluster: MulticlusterConfiguration{
+				Enabled:   in.Spec.Multicluster.Enabled,
+				Namespace: in.Spec.Multicluster.Namespace,
+				Gateway: GatewayConfiguration{
+					Port:         in.Spec.Multicluster.Gateway.Port,
+					ProbePort:    in.Spec.Multicluster.Gateway.ProbePort,
+					ProbePath:    in.Spec.Multicluster.Gateway.ProbePath,
+					ProbeSeconds: in.Spec.Multicluster.Gateway.ProbeSeconds,
+					ServiceType:  in.Spec.Multicluster.Gateway.ServiceType,
+				},
+				ServiceMirror: ServiceMirrorConfiguration{
+					LogLevel:          in.Spec.Multicluster.ServiceMirror.LogLevel,
+					EventRequeueLimit: in.Spec.Multicluster.ServiceMirror.EventRequeueLimit,
+				},
+				RemoteMirrorServiceAccountName: in.Spec.Multicluster.RemoteMirrorServiceAccountName,
+			},
+		},
+		Admission: AdmissionConfiguration{
+			ProxyInjector: ProxyInjectorWebhook{
+				FailurePolicy:      in.Spec.ProxyInjector.FailurePolicy,
+				NamespaceSelector:  in.Spec.ProxyInjector.NamespaceSelector,
+				ObjectSelector:     in.Spec.ProxyInjector.ObjectSelector,
+				TimeoutSeconds:     in.Spec.ProxyInjector.TimeoutSeconds,
+				ReinvocationPolicy: in.Spec.ProxyInjector.ReinvocationPolicy,
+			},
+			SPValidator: SPValidatorWebhook{
+				FailurePolicy: in.Spec.SPValidator.FailurePolicy,
+			},
+		},
+		AddOns: AddOnsConfiguration{
+			Grafana: GrafanaConfiguration{
+				Enabled:      in.Spec.Grafana.Enabled,
+				ExternalAddr: in.Spec.Grafana.ExternalAddr,
+			},
+			Heartbeat: HeartbeatConfiguration{
+				Enabled:  in.Spec.Heartbeat.Enabled,
+				Schedule: in.Spec.Heartbeat.Schedule,
+				Endpoint: in.Spec.Heartbeat.Endpoint,
+			},
+			Tracing: TracingConfiguration{
+				Enabled:            in.Spec.Tracing.Enabled,
+				SamplingPercentage: in.Spec.Tracing.Collector.SamplingPercentage,
+				ExternalAddr:       in.Spec.Tracing.Collector.ExternalAddr,
+				ExternalIdentity:   in.Spec.Tracing.Collector.ExternalIdentity,
+			},
+		},
+		Upgrade: UpgradeConfiguration(in.Spec.Upgrade),
+	}
+	if anchors := in.Spec.Identity.TrustAnchors; anchors != nil {
+		dst.Spec.Identity.TrustAnchors = &TrustAnchorsReference{Secret: anchors.Secret, ConfigMap: anchors.ConfigMap}
+	}
+	if window := in.Spec.DataPlane.MaintenanceWindow; window != nil {
+		dst.Spec.Proxy.Restarts.MaintenanceWindow = &MaintenanceWindow{Start: window.Start, Duration: window.Duration}
+		for _, day := range window.Days {
+			dst.Spec.Proxy.Restarts.MaintenanceWindow.Days = append(dst.Spec.Proxy.Restarts.MaintenanceWindow.Days, Weekday(day))
+		}
+	}
+	convertPrometheusFrom(&in.Spec.Prometheus, &dst.Spec.AddOns.Prometheus)
+
+	for name, base := range components(&in.Spec) {
+		if reflect.DeepEqual(*base, v1alpha1.BaseK8sResourceConfiguration{}) {
+			continue
+		}
+		if dst.Spec.Components == nil {
+			dst.Spec.Components = make(map[string]ComponentConfiguration)
+		}
+		dst.Spec.Components[name] = ComponentConfiguration(*base)
+	}
+
+	if certificates := in.Spec.SelfSignedCertificates; certificates != nil {
+		raw, err := json.Marshal(certificates)
+		if err != nil {
+			return emperror.Wrap(err, "could not encode the self signed certificates")
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string)
+		}
+		dst.Annotations[CertificatesAnnotation] = string(raw)
+	}
+
+	return convertJSON(&in.Status, &dst.Status)
+}
+
+// components returns the pod settings of the v1alpha1 spec, keyed as in the components map
+func components(spec *v1alpha1.LinkerdSpec) map[string]*v1alpha1.BaseK8sResourceConfiguration {
+	return map[string]*v1alpha1.BaseK8sResourceConfiguration{
+		ComponentCNI:                 &spec.CNI.BaseK8sResourceConfiguration,
+		ComponentController:          &spec.Controller.BaseK8sResourceConfiguration,
+		ComponentDestination:         &spec.Destination.BaseK8sResourceConfiguration,
+		ComponentGrafana:             &spec.Grafana.BaseK8sResourceConfiguration,
+		ComponentHeartbeat:           &spec.Heartbeat.BaseK8sResourceConfiguration,
+		ComponentIdentity:            &spec.Identity.BaseK8sResourceConfiguration,
+		ComponentMulticlusterGateway: &spec.Multicluster.Gateway.BaseK8sResourceConfiguration,
+		ComponentServiceMirror:       &spec.Multicluster.ServiceMirror.BaseK8sResourceConfiguration,
+		ComponentPrometheus:          &spec.Prometheus.BaseK8sResourceConfiguration,
+		ComponentProxyInjector:       &spec.ProxyInjector.BaseK8sResourceConfiguration,
+		ComponentSPValidator:         &spec.SPValidator.BaseK8sResourceConfiguration,
+		ComponentTap:                 &spec.Tap.BaseK8sResourceConfiguration,
+		ComponentTracingCollector:    &spec.Tracing.Collector.BaseK8sResourceConfiguration,
+		ComponentTracingJaeger:       &spec.Tracing.Jaeger.BaseK8sResourceConfiguration,
+		ComponentWeb:                 &spec.Web.BaseK8sResourceConfiguration,
+	}
+}
+
+func convertPrometheusTo(in *PrometheusConfiguration, out *v1alpha1.PrometheusConfiguration) {
+	out.URL = in.URL
+	out.ScrapeInterval = in.ScrapeInterval
+	out.ExternalLabels = in.ExternalLabels
+	out.AdditionalScrapeConfigs = in.AdditionalScrapeConfigs
+	out.RuleFiles = in.RuleFiles
+	if in.Credentials != nil {
+		out.Credentials = &v1alpha1.PrometheusCredentials{
+			SecretName: in.Credentials.SecretRef.Name,
+			Type:       v1alpha1.PrometheusAuthType(in.Credentials.Type),
+		}
+	}
+	for _, w := range in.RemoteWrite {
+		out.RemoteWrite = append(out.RemoteWrite, v1alpha1.PrometheusRemoteWrite(w))
+	}
+	out.Persistence = v1alpha1.PrometheusPersistence(in.Persistence)
+	out.Operator = v1alpha1.PrometheusOperatorConfiguration(in.Operator)
+	out.Alerts = v1alpha1.PrometheusAlertsConfiguration(in.Alerts)
+}
+
+func convertPrometheusFrom(in *v1alpha1.PrometheusConfiguration, out *PrometheusConfiguration) {
+	out.URL = in.URL
+	out.ScrapeInterval = in.ScrapeInterval
+	out.ExternalLabels = in.ExternalLabels
+	out.AdditionalScrapeConfigs = in.AdditionalScrapeConfigs
+	out.RuleFiles = in.RuleFiles
+	if in.Credentials != nil {
+		out.Credentials = &PrometheusCredentials{
+			Type: string(in.Credentials.Type),
+		}
+		out.Credentials.SecretRef.Name = in.Credentials.SecretName
+	}
+	for _, w := range in.RemoteWrite {
+		out.RemoteWrite = append(out.RemoteWrite, PrometheusRemoteWrite(w))
+	}
+	out.Persistence = PrometheusPersistence(in.Persistence)
+	out.Operator = PrometheusOperatorConfiguration(in.Operator)
+	out.Alerts = PrometheusAlertsConfiguration(in.Alerts)
+}
+
+// convertJSON copies between the versions of the types whose schema did not change
+func convertJSON(in, out interface{}) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return emperror.Wrap(err, "could not encode")
+	}
+	return emperror.Wrap(json.Unmarshal(raw, out), "could not decode")
+}
@@ -0,0 +1,230 @@
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+func base(image string) v1alpha1.BaseK8sResourceConfiguration {
+	return v1alpha1.BaseK8sResourceConfiguration{
+		Image:          util.StrPointer(image),
+		NodeSelector:   map[string]string{"kubernetes.io/os": "linux"},
+		PodAnnotations: map[string]string{"component": image},
+		ReplicaCount:   util.IntPointer(2),
+	}
+}
+
+func hub() *v1alpha1.Linkerd {
+	size := resource.MustParse("10Gi")
+	return &v1alpha1.Linkerd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "linkerd",
+			Namespace:   "linkerd",
+			Annotations: map[string]string{"owner": "platform"},
+		},
+		Spec: v1alpha1.LinkerdSpec{
+			Version:                 "stable-2.8.1",
+			Revision:                "blue",
+			HighAvailability:        util.BoolPointer(true),
+			LogLevel:                "debug",
+			AutoInjectionNamespaces: []string{"emojivoto"},
+			ImagePullPolicy:         corev1.PullAlways,
+			ProxyInit: v1alpha1.ProxyInitConfiguration{
+				IgnoreInboundPorts:  []string{"5432"},
+				IgnoreOutboundPorts: []string{"5000-5010"},
+				SkipSubnets:         []string{"10.0.0.0/8"},
+				IptablesMode:        v1alpha1.IptablesModeNft,
+			},
+			CNI: v1alpha1.CNIConfiguration{
+				BaseK8sResourceConfiguration: base("cni"),
+				Enabled:                      true,
+				DestCNINetDir:                "/etc/cni/net.d",
+				DestCNIBinDir:                "/opt/cni/bin",
+				LogLevel:                     "info",
+			},
+			Controller: v1alpha1.ControllerConfiguration{BaseK8sResourceConfiguration: base("controller")},
+			DataPlane: v1alpha1.DataPlaneConfiguration{
+				AutoRestart:   true,
+				BatchSize:     util.IntPointer(5),
+				BatchInterval: &metav1.Duration{Duration: time.Minute},
+				MaxConcurrent: util.IntPointer(2),
+				MaintenanceWindow: &v1alpha1.MaintenanceWindow{
+					Start:    "02:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+					Days:     []v1alpha1.Weekday{"Saturday", "Sunday"},
+				},
+			},
+			Destination: v1alpha1.DestinationConfiguration{BaseK8sResourceConfiguration: base("destination")},
+			Grafana: v1alpha1.GrafanaConfiguration{
+				BaseK8sResourceConfiguration: base("grafana"),
+				Enabled:                      util.BoolPointer(false),
+				ExternalAddr:                 "grafana.monitoring:3000",
+			},
+			Heartbeat: v1alpha1.HeartbeatConfiguration{
+				BaseK8sResourceConfiguration: base("heartbeat"),
+				Enabled:                      util.BoolPointer(true),
+				Schedule:                     "0 0 * * *",
+				Endpoint:                     "http://proxy:3128",
+			},
+			Identity: v1alpha1.IdentityConfiguration{
+				BaseK8sResourceConfiguration: base("identity"),
+				TrustAnchors: &v1alpha1.TrustAnchorsReference{
+					ConfigMap: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "trust-anchors"},
+						Key:                  "ca.crt",
+					},
+				},
+				Issuer:             v1alpha1.IssuerConfiguration{SigningSecret: "signer"},
+				CertificatesSecret: "linkerd-self-signed-certs",
+			},
+			Multicluster: v1alpha1.MulticlusterConfiguration{
+				Enabled:   true,
+				Namespace: "linkerd-multicluster",
+				Gateway: v1alpha1.GatewayConfiguration{
+					BaseK8sResourceConfiguration: base("gateway"),
+					Port:                         util.IntPointer(4143),
+					ProbePort:                    util.IntPointer(4181),
+					ProbePath:                    "/health",
+					ProbeSeconds:                 util.IntPointer(3),
+					ServiceType:                  corev1.ServiceTypeNodePort,
+				},
+				ServiceMirror: v1alpha1.ServiceMirrorConfiguration{
+					BaseK8sResourceConfiguration: base("service-mirror"),
+					LogLevel:                     "debug",
+					EventRequeueLimit:            util.IntPointer(3),
+				},
+				RemoteMirrorServiceAccountName: "mirror",
+			},
+			Prometheus: v1alpha1.PrometheusConfiguration{
+				BaseK8sResourceConfiguration: base("prometheus"),
+				URL:                          "http://prometheus.monitoring:9090",
+				Credentials:                  &v1alpha1.PrometheusCredentials{SecretName: "prometheus", Type: v1alpha1.PrometheusAuthBasic},
+				ScrapeInterval:               "10s",
+				ExternalLabels:               map[string]string{"cluster": "east"},
+				AdditionalScrapeConfigs:      "- job_name: extra",
+				RemoteWrite:                  []v1alpha1.PrometheusRemoteWrite{{URL: "http://cortex/push", RemoteTimeout: "30s"}},
+				RuleFiles:                    map[string]string{"extra.yml": "groups: []"},
+				Persistence: v1alpha1.PrometheusPersistence{
+					Enabled:          true,
+					StorageClassName: util.StrPointer("fast"),
+					Size:             &size,
+					RetentionTime:    "6h",
+					RetentionSize:    "5GB",
+				},
+				Operator: v1alpha1.PrometheusOperatorConfiguration{Enabled: true, Labels: map[string]string{"release": "monitoring"}},
+				Alerts: v1alpha1.PrometheusAlertsConfiguration{
+					Enabled:                    util.BoolPointer(true),
+					ComponentDownFor:           "5m",
+					FailuresFor:                "5m",
+					CertificateExpiryThreshold: "6h",
+					ProxyErrorRatePercentage:   util.IntPointer(10),
+				},
+			},
+			ProxyInjector: v1alpha1.ProxyInjectorConfiguration{
+				BaseK8sResourceConfiguration: base("proxy-injector"),
+				FailurePolicy:                admissionregistrationv1.Fail,
+				NamespaceSelector:            &metav1.LabelSelector{MatchLabels: map[string]string{"mesh": "true"}},
+				ObjectSelector:               &metav1.LabelSelector{MatchLabels: map[string]string{"inject": "true"}},
+				TimeoutSeconds:               util.IntPointer(10),
+				ReinvocationPolicy:           admissionregistrationv1.IfNeededReinvocationPolicy,
+			},
+			SPValidator: v1alpha1.SPValidatorConfiguration{
+				BaseK8sResourceConfiguration: base("sp-validator"),
+				FailurePolicy:                admissionregistrationv1.Ignore,
+			},
+			Tap: v1alpha1.TapConfiguration{BaseK8sResourceConfiguration: base("tap")},
+			Tracing: v1alpha1.TracingConfiguration{
+				Enabled: true,
+				Collector: v1alpha1.CollectorConfiguration{
+					BaseK8sResourceConfiguration: base("collector"),
+					SamplingPercentage:           util.IntPointer(50),
+					ExternalIdentity:             "collector.tracing.serviceaccount.identity.linkerd.cluster.local",
+				},
+				Jaeger: v1alpha1.JaegerConfiguration{BaseK8sResourceConfiguration: base("jaeger")},
+			},
+			Upgrade: v1alpha1.UpgradeConfiguration{ComponentTimeout: &metav1.Duration{Duration: 10 * time.Minute}},
+			Web:     v1alpha1.WebConfiguration{BaseK8sResourceConfiguration: base("web")},
+		},
+		Status: v1alpha1.LinkerdStatus{
+			Status:  v1alpha1.Available,
+			Version: "stable-2.8.1",
+			Conditions: []v1alpha1.LinkerdCondition{
+				{Type: v1alpha1.ConditionVersionSupported, Status: corev1.ConditionTrue, Reason: "Found"},
+			},
+			Multicluster: &v1alpha1.GatewayStatus{Alive: true, Addresses: []string{"10.0.0.1"}},
+		},
+	}
+}
+
+func TestConvertFromHub(t *testing.T) {
+	src := hub()
+	dst := &Linkerd{}
+	require.NoError(t, dst.ConvertFrom(src))
+
+	assert.Len(t, dst.Spec.Components, len(components(&src.Spec)))
+	assert.Equal(t, util.StrPointer("gateway"), dst.Spec.Components[ComponentMulticlusterGateway].Image)
+	assert.Equal(t, "prometheus", dst.Spec.AddOns.Prometheus.Credentials.SecretRef.Name)
+	assert.Equal(t, []string{"emojivoto"}, dst.Spec.Proxy.AutoInjectionNamespaces)
+	assert.Equal(t, "linkerd-self-signed-certs", dst.Spec.Identity.CertificatesSecret)
+	assert.Equal(t, map[string]string{"owner": "platform"}, dst.Annotations)
+
+	back := &v1alpha1.Linkerd{}
+	require.NoError(t, dst.ConvertTo(back))
+	assert.True(t, equality.Semantic.DeepEqual(src, back), "the round trip must not lose anything")
+}
+
+func TestConvertFromHubKeepsCertificates(t *testing.T) {
+	src := hub()
+	src.Spec.SelfSignedCertificates = &v1alpha1.SelfSignedCertificates{
+		TrustAnchorsPEM: "anchors",
+		KeyPEM:          "key",
+		CrtPEM:          "crt",
+	}
+	dst := &Linkerd{}
+	require.NoError(t, dst.ConvertFrom(src))
+	assert.Contains(t, dst.Annotations, CertificatesAnnotation)
+	assert.Equal(t, "platform", dst.Annotations["owner"])
+	assert.NotContains(t, src.Annotations, CertificatesAnnotation, "the hub must not be modified")
+
+	back := &v1alpha1.Linkerd{}
+	require.NoError(t, dst.ConvertTo(back))
+	assert.Equal(t, src.Spec.SelfSignedCertificates, back.Spec.SelfSignedCertificates, "the trust root must survive the round trip")
+	assert.True(t, equality.Semantic.DeepEqual(src, back), "the round trip must not lose anything")
+}
+
+func TestConvertToHub(t *testing.T) {
+	src := &Linkerd{
+		ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: "linkerd"},
+		Spec: LinkerdSpec{
+			Version: "stable-2.8.1",
+			Components: map[string]ComponentConfiguration{
+				ComponentTap: {ReplicaCount: util.IntPointer(3)},
+			},
+			AddOns: AddOnsConfiguration{
+				Tracing: TracingConfiguration{Enabled: true, ExternalAddr: "collector:55678"},
+			},
+		},
+	}
+	dst := &v1alpha1.Linkerd{}
+	require.NoError(t, src.ConvertTo(dst))
+
+	assert.Equal(t, util.IntPointer(3), dst.Spec.Tap.ReplicaCount)
+	assert.Equal(t, "collector:55678", dst.Spec.Tracing.Collector.ExternalAddr)
+	assert.Nil(t, dst.Spec.SelfSignedCertificates)
+	assert.Nil(t, dst.Annotations)
+
+	back := &Linkerd{}
+	require.NoError(t, back.ConvertFrom(dst))
+	assert.True(t, equality.Semantic.DeepEqual(src, back), "the round trip must not lose anything")
+}
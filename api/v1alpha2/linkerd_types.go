@@ -0,0 +1,489 @@
+/*
+Copyright 2020 The Linkerd2 Operator authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Keys of the components map, one per deployment of the control plane and of the add-ons
+const (
+	ComponentCNI                 = "cni"
+	ComponentController          = "controller"
+	ComponentDestination         = "destination"
+	ComponentGrafana             = "grafana"
+	ComponentHeartbeat           = "heartbeat"
+	ComponentIdentity            = "identity"
+	ComponentMulticlusterGateway = "multicluster-gateway"
+	ComponentServiceMirror       = "service-mirror"
+	ComponentPrometheus          = "prometheus"
+	ComponentProxyInjector       = "proxy-injector"
+	ComponentSPValidator         = "sp-validator"
+	ComponentTap                 = "tap"
+	ComponentTracingCollector    = "tracing-collector"
+	ComponentTracingJaeger       = "tracing-jaeger"
+	ComponentWeb                 = "web"
+)
+
+// ComponentConfiguration defines the pods of a component
+type ComponentConfiguration struct {
+	Image           *string                      `json:"image,omitempty"`
+	Resources       *corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector    map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity        *corev1.Affinity             `json:"affinity,omitempty"`
+	Tolerations     []corev1.Toleration          `json:"tolerations,omitempty"`
+	PodAnnotations  map[string]string            `json:"podAnnotations,omitempty"`
+	SecurityContext *corev1.SecurityContext      `json:"securityContext,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	ReplicaCount *int32 `json:"replicaCount,omitempty"`
+}
+
+// IdentityConfiguration defines the trust anchors and the issuer of the proxy certificates. The certificates
+// are generated by the operator unless the trust anchors are referenced
+type IdentityConfiguration struct {
+	// TrustAnchors references the PEM bundle of the roots, e.g. shared with other clusters
+	TrustAnchors *TrustAnchorsReference `json:"trustAnchors,omitempty"`
+	// Issuer defines where the issuer comes from, it must chain to the trust anchors
+	Issuer IssuerConfiguration `json:"issuer,omitempty"`
+	// CertificatesSecret is the Secret of the namespace holding the self signed certificates, set by the operator.
+	// The certificates of the v1alpha1 resources are moved to it on their first reconcile
+	CertificatesSecret string `json:"certificatesSecret,omitempty"`
+}
+
+// TrustAnchorsReference references a key of a Secret or of a ConfigMap of the namespace of the Linkerd resource
+type TrustAnchorsReference struct {
+	Secret    *corev1.SecretKeySelector    `json:"secret,omitempty"`
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+}
+
+// IssuerConfiguration defines the issuer of the identity certificates. The Secrets are kubernetes.io/tls
+// Secrets of the namespace of the Linkerd resource, only one of them is set
+type IssuerConfiguration struct {
+	// SigningSecret holds a CA chaining to the trust anchors, the operator issues the issuer from it
+	SigningSecret string `json:"signingSecret,omitempty"`
+	// ExternalSecret holds an issuer signed outside of the operator, e.g. by cert-manager
+	ExternalSecret string `json:"externalSecret,omitempty"`
+}
+
+// ProxyConfiguration defines which workloads get a proxy and how they are restarted to get a new one
+type ProxyConfiguration struct {
+	// AutoInjectionNamespaces are labeled for the proxy injection
+	AutoInjectionNamespaces []string `json:"autoInjectionNamespaces,omitempty"`
+	// Restarts of the workloads running an outdated proxy
+	Restarts RestartsConfiguration `json:"restarts,omitempty"`
+}
+
+// RestartsConfiguration defines how the workloads are restarted to get the proxy of the current version
+type RestartsConfiguration struct {
+	// AutoRestart restarts the workloads running an outdated proxy, or no proxy in an injected namespace
+	AutoRestart bool `json:"autoRestart,omitempty"`
+	// BatchSize is the number of workloads restarted together
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *int32 `json:"batchSize,omitempty"`
+	// BatchInterval is the minimum time between two batches
+	BatchInterval *metav1.Duration `json:"batchInterval,omitempty"`
+	// MaxConcurrent is the maximum number of workloads rolling out at the same time
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+	// MaintenanceWindow restricts the restarts to a recurring window, restarts happen at any time when not set
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindow is a recurring time window, in UTC
+type MaintenanceWindow struct {
+	// Start of the window formatted as HH:MM
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration of the window
+	Duration metav1.Duration `json:"duration"`
+	// Days of the week the window opens, every day when empty
+	Days []Weekday `json:"days,omitempty"`
+}
+
+// Weekday is a day of the week
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type Weekday string
+
+// NetworkingConfiguration defines how the traffic reaches the proxies, within and across clusters
+type NetworkingConfiguration struct {
+	// ProxyInit configures the iptables rules redirecting the traffic through the proxy
+	ProxyInit ProxyInitConfiguration `json:"proxyInit,omitempty"`
+	// CNI sets up the iptables rules from a CNI plugin instead of the proxy-init containers
+	CNI CNIConfiguration `json:"cni,omitempty"`
+	// Multicluster configures the gateway receiving the traffic of the linked clusters
+	Multicluster MulticlusterConfiguration `json:"multicluster,omitempty"`
+}
+
+// ProxyInitConfiguration defines the iptables rules set up by proxy-init or by the CNI plugin
+type ProxyInitConfiguration struct {
+	// IgnoreInboundPorts is the list of inbound ports or port ranges (e.g. 5432 or 5000-5010) that bypass the proxy
+	IgnoreInboundPorts []string `json:"ignoreInboundPorts,omitempty"`
+	// IgnoreOutboundPorts is the list of outbound ports or port ranges that bypass the proxy
+	IgnoreOutboundPorts []string `json:"ignoreOutboundPorts,omitempty"`
+	// SkipSubnets is the list of CIDRs whose traffic bypasses the proxy
+	SkipSubnets []string `json:"skipSubnets,omitempty"`
+	// IptablesMode selects the iptables backend used to configure the rules
+	// +kubebuilder:validation:Enum=legacy;nft
+	IptablesMode string `json:"iptablesMode,omitempty"`
+}
+
+// CNIConfiguration defines the linkerd CNI plugin
+type CNIConfiguration struct {
+	// Enabled installs the linkerd-cni DaemonSet which replaces the proxy-init containers. The plugin is shared
+	// by the control planes, it is installed in the linkerd-cni namespace with the settings of the oldest Linkerd
+	// resource enabling it
+	Enabled bool `json:"enabled,omitempty"`
+	// DestCNINetDir is the directory on the host where the CNI configuration is installed
+	DestCNINetDir string `json:"destCNINetDir,omitempty"`
+	// DestCNIBinDir is the directory on the host where the CNI binaries are installed
+	DestCNIBinDir string `json:"destCNIBinDir,omitempty"`
+	// LogLevel is the log level of the CNI plugin
+	LogLevel string `json:"logLevel,omitempty"`
+}
+
+// MulticlusterConfiguration defines the gateway receiving the traffic of the linked clusters.
+// The clusters to mirror the services of are defined by the Links of its namespace
+type MulticlusterConfiguration struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Namespace holds the gateway and the Links to the other clusters
+	Namespace string `json:"namespace,omitempty"`
+	// Gateway configuration options
+	Gateway GatewayConfiguration `json:"gateway,omitempty"`
+	// ServiceMirror configuration options, applied to the service mirror of every Link
+	ServiceMirror ServiceMirrorConfiguration `json:"serviceMirror,omitempty"`
+	// RemoteMirrorServiceAccountName is the service account the service mirrors of the other clusters use
+	// to read the services of this cluster
+	RemoteMirrorServiceAccountName string `json:"remoteMirrorServiceAccountName,omitempty"`
+}
+
+// GatewayConfiguration defines the ports of the multicluster gateway
+type GatewayConfiguration struct {
+	// Port receives the traffic of the other clusters
+	Port *int32 `json:"port,omitempty"`
+	// ProbePort serves the health checks of the service mirrors of the other clusters
+	ProbePort *int32 `json:"probePort,omitempty"`
+	ProbePath string `json:"probePath,omitempty"`
+	// ProbeSeconds is how often the service mirrors of the other clusters probe the gateway
+	ProbeSeconds *int32 `json:"probeSeconds,omitempty"`
+	// ServiceType exposes the gateway to the other clusters
+	// +kubebuilder:validation:Enum=LoadBalancer;NodePort;ClusterIP
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+}
+
+// ServiceMirrorConfiguration defines the service mirrors
+type ServiceMirrorConfiguration struct {
+	LogLevel string `json:"logLevel,omitempty"`
+	// EventRequeueLimit is how many times a failed update of a mirrored service is retried
+	EventRequeueLimit *int32 `json:"eventRequeueLimit,omitempty"`
+}
+
+// AdmissionConfiguration defines the admission webhooks of the control plane
+type AdmissionConfiguration struct {
+	// ProxyInjector configures the webhook adding the proxies to the pods
+	ProxyInjector ProxyInjectorWebhook `json:"proxyInjector,omitempty"`
+	// SPValidator configures the webhook validating the ServiceProfiles
+	SPValidator SPValidatorWebhook `json:"spValidator,omitempty"`
+}
+
+// ProxyInjectorWebhook defines the registration of the proxy injector
+type ProxyInjectorWebhook struct {
+	// FailurePolicy defines how the API server handles pods when the injector is unavailable.
+	// Defaults to Fail with high availability, so that pods are never created without a proxy
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	FailurePolicy admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+	// NamespaceSelector selects the namespaces whose pods are sent to the injector. The namespaces of
+	// the other revisions and the ones labeled config.linkerd.io/admission-webhooks: disabled, such as the
+	// namespace of the control plane, are always excluded
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ObjectSelector selects the pods sent to the injector
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+	// TimeoutSeconds is how long the API server waits for the injector
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// ReinvocationPolicy defines whether the injector is called again after the other mutating webhooks
+	// +kubebuilder:validation:Enum=Never;IfNeeded
+	ReinvocationPolicy admissionregistrationv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty"`
+}
+
+// SPValidatorWebhook defines the registration of the service profile validator
+type SPValidatorWebhook struct {
+	// FailurePolicy defines how the API server handles ServiceProfiles when the validator is unavailable
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	FailurePolicy admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+}
+
+// AddOnsConfiguration defines the optional components, their pods are configured in the components map
+type AddOnsConfiguration struct {
+	Grafana    GrafanaConfiguration    `json:"grafana,omitempty"`
+	Heartbeat  HeartbeatConfiguration  `json:"heartbeat,omitempty"`
+	Prometheus PrometheusConfiguration `json:"prometheus,omitempty"`
+	Tracing    TracingConfiguration    `json:"tracing,omitempty"`
+}
+
+// GrafanaConfiguration defines the grafana the dashboard links to
+type GrafanaConfiguration struct {
+	// Enabled deploys the bundled grafana with the Linkerd dashboards
+	Enabled *bool `json:"enabled,omitempty"`
+	// ExternalAddr is the address (host:port) of an existing grafana instance the dashboard links to.
+	// When set, the bundled grafana is not deployed
+	ExternalAddr string `json:"externalAddr,omitempty"`
+}
+
+// HeartbeatConfiguration defines the heartbeat cronjob
+type HeartbeatConfiguration struct {
+	// Enabled schedules the heartbeat reporting anonymous usage data to the Linkerd maintainers
+	Enabled *bool `json:"enabled,omitempty"`
+	// Schedule of the heartbeat in cron format
+	Schedule string `json:"schedule,omitempty"`
+	// Endpoint is an HTTP(S) relay the heartbeat is sent through, for clusters that cannot
+	// reach versioncheck.linkerd.io directly. It is passed to the job as HTTPS_PROXY
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// PrometheusConfiguration defines the prometheus queried by the control plane
+type PrometheusConfiguration struct {
+	// URL of an existing Prometheus queried by the control plane (e.g. http://prometheus.monitoring:9090).
+	// When set, the bundled prometheus is not deployed
+	URL string `json:"url,omitempty"`
+	// Credentials used to query the Prometheus referenced by URL
+	Credentials *PrometheusCredentials `json:"credentials,omitempty"`
+	// ScrapeInterval is how frequently the bundled prometheus scrapes its targets (e.g. 10s)
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+	// ExternalLabels are added to the time series and alerts sent to external systems
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+	// AdditionalScrapeConfigs is a YAML list of scrape configs appended to the Linkerd ones
+	AdditionalScrapeConfigs string `json:"additionalScrapeConfigs,omitempty"`
+	// RemoteWrite is the list of endpoints the samples are forwarded to
+	RemoteWrite []PrometheusRemoteWrite `json:"remoteWrite,omitempty"`
+	// RuleFiles are recording and alerting rule files, keyed by file name
+	RuleFiles map[string]string `json:"ruleFiles,omitempty"`
+	// Persistence configuration options
+	Persistence PrometheusPersistence `json:"persistence,omitempty"`
+	// Operator configures the integration with prometheus-operator
+	Operator PrometheusOperatorConfiguration `json:"operator,omitempty"`
+	// Alerts configures the control plane alerting rules
+	Alerts PrometheusAlertsConfiguration `json:"alerts,omitempty"`
+}
+
+// PrometheusCredentials references the Secret holding the credentials of an external Prometheus
+type PrometheusCredentials struct {
+	// SecretRef references the Secret in the Linkerd namespace
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+	// Type of the credentials stored in the Secret, the username and password keys for basic
+	// +kubebuilder:validation:Enum=basic
+	Type string `json:"type"`
+}
+
+// PrometheusRemoteWrite defines a remote_write endpoint of the bundled prometheus
+type PrometheusRemoteWrite struct {
+	// URL of the endpoint the samples are sent to
+	URL string `json:"url"`
+	// RemoteTimeout is the timeout of the requests to the endpoint (e.g. 30s)
+	RemoteTimeout string `json:"remoteTimeout,omitempty"`
+}
+
+// PrometheusPersistence defines the storage of the bundled prometheus
+type PrometheusPersistence struct {
+	// Enabled stores the metrics in a PersistentVolumeClaim instead of an emptyDir. A single replica of
+	// prometheus runs then, since the pods cannot share the ReadWriteOnce claim
+	Enabled bool `json:"enabled,omitempty"`
+	// StorageClassName of the claim, the default storage class is used when empty
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Size of the claim
+	Size *resource.Quantity `json:"size,omitempty"`
+	// RetentionTime is how long the samples are kept (e.g. 6h)
+	RetentionTime string `json:"retentionTime,omitempty"`
+	// RetentionSize is the maximum size of the stored samples (e.g. 5GB)
+	// +kubebuilder:validation:Pattern=`^[0-9]+(B|KB|MB|GB|TB|PB|EB)$`
+	RetentionSize string `json:"retentionSize,omitempty"`
+}
+
+// PrometheusOperatorConfiguration defines the integration with prometheus-operator
+type PrometheusOperatorConfiguration struct {
+	// Enabled creates PodMonitor objects instead of deploying the bundled prometheus.
+	// The prometheus-operator managed instance is queried through URL, which is required in this mode
+	Enabled bool `json:"enabled,omitempty"`
+	// Labels are added to the monitoring objects, so that they are selected by the Prometheus instance
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PrometheusAlertsConfiguration defines the thresholds of the control plane alerting rules
+type PrometheusAlertsConfiguration struct {
+	// Enabled ships the alerting rules, in the prometheus configuration or as a PrometheusRule
+	Enabled *bool `json:"enabled,omitempty"`
+	// ComponentDownFor is how long a control plane component is unreachable before alerting (e.g. 5m)
+	ComponentDownFor string `json:"componentDownFor,omitempty"`
+	// FailuresFor is how long identity issuance or proxy injection fail before alerting (e.g. 5m)
+	FailuresFor string `json:"failuresFor,omitempty"`
+	// CertificateExpiryThreshold is the minimum remaining validity of the proxy certificates (e.g. 6h)
+	CertificateExpiryThreshold string `json:"certificateExpiryThreshold,omitempty"`
+	// ProxyErrorRatePercentage is the percentage of failed responses of a workload above which to alert
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ProxyErrorRatePercentage *int32 `json:"proxyErrorRatePercentage,omitempty"`
+}
+
+// TracingConfiguration defines the distributed tracing add-on
+type TracingConfiguration struct {
+	// Enabled configures the control plane to export its spans to the trace collector
+	Enabled bool `json:"enabled,omitempty"`
+	// SamplingPercentage is the percentage of traces sampled by the collector
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercentage *int32 `json:"samplingPercentage,omitempty"`
+	// ExternalAddr is the address (host:port) of an existing OpenCensus collector the spans are exported to.
+	// When set, neither the bundled collector nor Jaeger are deployed
+	ExternalAddr string `json:"externalAddr,omitempty"`
+	// ExternalIdentity is the TLS identity of the external collector, if it is part of the mesh
+	ExternalIdentity string `json:"externalIdentity,omitempty"`
+}
+
+// UpgradeConfiguration defines how the control plane is upgraded when the version changes
+type UpgradeConfiguration struct {
+	// ComponentTimeout is the time a component has to finish rolling out before the upgrade is rolled back
+	ComponentTimeout *metav1.Duration `json:"componentTimeout,omitempty"`
+}
+
+// LinkerdSpec defines the desired state of Linkerd
+type LinkerdSpec struct {
+	// Version is the intended Linkerd version
+	Version string `json:"version"`
+	// Revision suffixes the name of every cluster-scoped resource of the control plane, so that several control planes
+	// run side by side, each in its own namespace. The namespaced resources keep their name, so the operator only
+	// reconciles the oldest Linkerd resource of a namespace
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=20
+	Revision string `json:"revision,omitempty"`
+	// HighAvailability makes the control plane resilient to the loss of a component
+	HighAvailability *bool `json:"highAvailability,omitempty"`
+	// LogLevel is the log level for the linkerd controller
+	LogLevel string `json:"logLevel,omitempty"`
+	// ImagePullPolicy describes a policy for if/when to pull a container image
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Components configures the pods of the components, keyed by component name
+	Components map[string]ComponentConfiguration `json:"components,omitempty"`
+	// Identity configuration options
+	Identity IdentityConfiguration `json:"identity,omitempty"`
+	// Proxy configuration options
+	Proxy ProxyConfiguration `json:"proxy,omitempty"`
+	// Networking configuration options
+	Networking NetworkingConfiguration `json:"networking,omitempty"`
+	// Admission configuration options
+	Admission AdmissionConfiguration `json:"admission,omitempty"`
+	// AddOns configuration options
+	AddOns AddOnsConfiguration `json:"addOns,omitempty"`
+	// Upgrade configuration options
+	Upgrade UpgradeConfiguration `json:"upgrade,omitempty"`
+}
+
+// LinkerdStatus defines the observed state of Linkerd
+type LinkerdStatus struct {
+	Status       string `json:"Status,omitempty"`
+	ErrorMessage string `json:"ErrorMessage,omitempty"`
+	// Conditions are the latest observations of the Linkerd resource
+	Conditions []LinkerdCondition `json:"conditions,omitempty"`
+	// Version is the Linkerd version every component runs
+	Version string `json:"version,omitempty"`
+	// Upgrade records the progress of the latest upgrade
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+	// DataPlane records the progress of the restarts of the workloads
+	DataPlane *DataPlaneStatus `json:"dataPlane,omitempty"`
+	// Multicluster records the state of the gateway
+	Multicluster *GatewayStatus `json:"multicluster,omitempty"`
+}
+
+// LinkerdCondition describes an observation of the Linkerd resource
+type LinkerdCondition struct {
+	Type   string                 `json:"type"`
+	Status corev1.ConditionStatus `json:"status"`
+	// Reason is a CamelCase reason of the last transition
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable explanation of the last transition
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// UpgradeStatus records the progress of an upgrade of the control plane
+type UpgradeStatus struct {
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+	Phase       string `json:"phase"`
+	// UpgradedComponents are the components running the new version, in upgrade order
+	UpgradedComponents []string `json:"upgradedComponents,omitempty"`
+	// CurrentComponent is the component rolling out the new version
+	CurrentComponent string `json:"currentComponent,omitempty"`
+	// CurrentComponentStartTime is when the rollout of the current component started
+	CurrentComponentStartTime *metav1.Time `json:"currentComponentStartTime,omitempty"`
+	// RolledBackGeneration is the generation of the spec that was rolled back, the upgrade is attempted again
+	// once the spec changes
+	RolledBackGeneration int64  `json:"rolledBackGeneration,omitempty"`
+	Message              string `json:"message,omitempty"`
+}
+
+// DataPlaneStatus records the progress of the restarts of the workloads
+type DataPlaneStatus struct {
+	// PendingWorkloads is the number of workloads running an outdated proxy or no proxy
+	PendingWorkloads int32 `json:"pendingWorkloads"`
+	// RestartingWorkloads are the workloads rolling out, as kind/namespace/name
+	RestartingWorkloads []string `json:"restartingWorkloads,omitempty"`
+	// LastRestartTime is when the latest batch was restarted
+	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
+	Message         string       `json:"message,omitempty"`
+}
+
+// GatewayStatus records the state of a multicluster gateway
+type GatewayStatus struct {
+	// Alive is true when the gateway service has ready endpoints
+	Alive bool `json:"alive"`
+	// Addresses are the addresses the other clusters reach the gateway at
+	Addresses []string `json:"addresses,omitempty"`
+	Message   string   `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Linkerd is the Schema for the linkerds API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.Status",description="Status of the resource"
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=".status.ErrorMessage",description="Error message"
+// +kubebuilder:resource:path=linkerds,scope=Namespaced
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Linkerd struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LinkerdSpec   `json:"spec,omitempty"`
+	Status LinkerdStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LinkerdList contains a list of Linkerd
+type LinkerdList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Linkerd `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Linkerd{}, &LinkerdList{})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Linkerd2 Operator authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+var linkerdlog = logf.Log.WithName("linkerd-resource")
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks of Linkerd. The conversion
+// webhook is registered along with them
+func (r *Linkerd) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-linkerd-linkerd-io-v1alpha2-linkerd,mutating=true,failurePolicy=fail,groups=linkerd.linkerd.io,resources=linkerds,verbs=create;update,versions=v1alpha2,name=mlinkerd.v1alpha2.kb.io
+
+var _ webhook.Defaulter = &Linkerd{}
+
+// Default persists the defaults of the v1alpha1 hub, so that both versions are defaulted the same way
+func (r *Linkerd) Default() {
+	hub := &v1alpha1.Linkerd{}
+	if err := r.ConvertTo(hub); err != nil {
+		linkerdlog.Error(err, "could not convert to the hub version", "namespace", r.Namespace, "name", r.Name)
+		return
+	}
+	hub.Default()
+	if err := r.ConvertFrom(hub); err != nil {
+		linkerdlog.Error(err, "could not convert from the hub version", "namespace", r.Namespace, "name", r.Name)
+	}
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-linkerd-linkerd-io-v1alpha2-linkerd,mutating=false,failurePolicy=fail,groups=linkerd.linkerd.io,resources=linkerds,versions=v1alpha2,name=vlinkerd.v1alpha2.kb.io
+
+var _ webhook.Validator = &Linkerd{}
+
+// ValidateCreate rejects the unknown components and the resources the hub version rejects
+func (r *Linkerd) ValidateCreate() error {
+	if err := r.validateComponents(); err != nil {
+		return err
+	}
+	hub := &v1alpha1.Linkerd{}
+	if err := r.ConvertTo(hub); err != nil {
+		return err
+	}
+	return hub.ValidateCreate()
+}
+
+// ValidateUpdate rejects the unknown components and the updates the hub version rejects
+func (r *Linkerd) ValidateUpdate(old runtime.Object) error {
+	if err := r.validateComponents(); err != nil {
+		return err
+	}
+	hub := &v1alpha1.Linkerd{}
+	if err := r.ConvertTo(hub); err != nil {
+		return err
+	}
+	previous := &v1alpha1.Linkerd{}
+	if o, ok := old.(*Linkerd); ok {
+		if err := o.ConvertTo(previous); err != nil {
+			return err
+		}
+	}
+	return hub.ValidateUpdate(previous)
+}
+
+// ValidateDelete accepts every deletion
+func (r *Linkerd) ValidateDelete() error {
+	return nil
+}
+
+// validateComponents rejects the keys of the components map no deployment matches, they would be
+// silently dropped by the conversion
+func (r *Linkerd) validateComponents() error {
+	known := components(&v1alpha1.LinkerdSpec{})
+	supported := make([]string, 0, len(known))
+	for name := range known {
+		supported = append(supported, name)
+	}
+	sort.Strings(supported)
+
+	names := make([]string, 0, len(r.Spec.Components))
+	for name := range r.Spec.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs field.ErrorList
+	for _, name := range names {
+		if _, ok := known[name]; !ok {
+			errs = append(errs, field.NotSupported(field.NewPath("spec", "components").Key(name), name, supported))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("Linkerd").GroupKind(), r.Name, errs)
+}
@@ -0,0 +1,875 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 The Linkerd2 Operator authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddOnsConfiguration) DeepCopyInto(out *AddOnsConfiguration) {
+	*out = *in
+	in.Grafana.DeepCopyInto(&out.Grafana)
+	in.Heartbeat.DeepCopyInto(&out.Heartbeat)
+	in.Prometheus.DeepCopyInto(&out.Prometheus)
+	in.Tracing.DeepCopyInto(&out.Tracing)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddOnsConfiguration.
+func (in *AddOnsConfiguration) DeepCopy() *AddOnsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AddOnsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionConfiguration) DeepCopyInto(out *AdmissionConfiguration) {
+	*out = *in
+	in.ProxyInjector.DeepCopyInto(&out.ProxyInjector)
+	out.SPValidator = in.SPValidator
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionConfiguration.
+func (in *AdmissionConfiguration) DeepCopy() *AdmissionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNIConfiguration) DeepCopyInto(out *CNIConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIConfiguration.
+func (in *CNIConfiguration) DeepCopy() *CNIConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CNIConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentConfiguration) DeepCopyInto(out *ComponentConfiguration) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicaCount != nil {
+		in, out := &in.ReplicaCount, &out.ReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentConfiguration.
+func (in *ComponentConfiguration) DeepCopy() *ComponentConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneStatus) DeepCopyInto(out *DataPlaneStatus) {
+	*out = *in
+	if in.RestartingWorkloads != nil {
+		in, out := &in.RestartingWorkloads, &out.RestartingWorkloads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRestartTime != nil {
+		in, out := &in.LastRestartTime, &out.LastRestartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneStatus.
+func (in *DataPlaneStatus) DeepCopy() *DataPlaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayConfiguration) DeepCopyInto(out *GatewayConfiguration) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProbePort != nil {
+		in, out := &in.ProbePort, &out.ProbePort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProbeSeconds != nil {
+		in, out := &in.ProbeSeconds, &out.ProbeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfiguration.
+func (in *GatewayConfiguration) DeepCopy() *GatewayConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayStatus) DeepCopyInto(out *GatewayStatus) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayStatus.
+func (in *GatewayStatus) DeepCopy() *GatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaConfiguration) DeepCopyInto(out *GrafanaConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaConfiguration.
+func (in *GrafanaConfiguration) DeepCopy() *GrafanaConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeartbeatConfiguration) DeepCopyInto(out *HeartbeatConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeartbeatConfiguration.
+func (in *HeartbeatConfiguration) DeepCopy() *HeartbeatConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityConfiguration) DeepCopyInto(out *IdentityConfiguration) {
+	*out = *in
+	if in.TrustAnchors != nil {
+		in, out := &in.TrustAnchors, &out.TrustAnchors
+		*out = new(TrustAnchorsReference)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Issuer = in.Issuer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityConfiguration.
+func (in *IdentityConfiguration) DeepCopy() *IdentityConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerConfiguration) DeepCopyInto(out *IssuerConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerConfiguration.
+func (in *IssuerConfiguration) DeepCopy() *IssuerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Linkerd) DeepCopyInto(out *Linkerd) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Linkerd.
+func (in *Linkerd) DeepCopy() *Linkerd {
+	if in == nil {
+		return nil
+	}
+	out := new(Linkerd)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Linkerd) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkerdCondition) DeepCopyInto(out *LinkerdCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdCondition.
+func (in *LinkerdCondition) DeepCopy() *LinkerdCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkerdCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkerdList) DeepCopyInto(out *LinkerdList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Linkerd, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdList.
+func (in *LinkerdList) DeepCopy() *LinkerdList {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkerdList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LinkerdList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkerdSpec) DeepCopyInto(out *LinkerdSpec) {
+	*out = *in
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]ComponentConfiguration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.Identity.DeepCopyInto(&out.Identity)
+	in.Proxy.DeepCopyInto(&out.Proxy)
+	in.Networking.DeepCopyInto(&out.Networking)
+	in.Admission.DeepCopyInto(&out.Admission)
+	in.AddOns.DeepCopyInto(&out.AddOns)
+	in.Upgrade.DeepCopyInto(&out.Upgrade)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdSpec.
+func (in *LinkerdSpec) DeepCopy() *LinkerdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkerdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkerdStatus) DeepCopyInto(out *LinkerdStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]LinkerdCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataPlane != nil {
+		in, out := &in.DataPlane, &out.DataPlane
+		*out = new(DataPlaneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Multicluster != nil {
+		in, out := &in.Multicluster, &out.Multicluster
+		*out = new(GatewayStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkerdStatus.
+func (in *LinkerdStatus) DeepCopy() *LinkerdStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkerdStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MulticlusterConfiguration) DeepCopyInto(out *MulticlusterConfiguration) {
+	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.ServiceMirror.DeepCopyInto(&out.ServiceMirror)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MulticlusterConfiguration.
+func (in *MulticlusterConfiguration) DeepCopy() *MulticlusterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MulticlusterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkingConfiguration) DeepCopyInto(out *NetworkingConfiguration) {
+	*out = *in
+	in.ProxyInit.DeepCopyInto(&out.ProxyInit)
+	out.CNI = in.CNI
+	in.Multicluster.DeepCopyInto(&out.Multicluster)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingConfiguration.
+func (in *NetworkingConfiguration) DeepCopy() *NetworkingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAlertsConfiguration) DeepCopyInto(out *PrometheusAlertsConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProxyErrorRatePercentage != nil {
+		in, out := &in.ProxyErrorRatePercentage, &out.ProxyErrorRatePercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAlertsConfiguration.
+func (in *PrometheusAlertsConfiguration) DeepCopy() *PrometheusAlertsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAlertsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusConfiguration) DeepCopyInto(out *PrometheusConfiguration) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(PrometheusCredentials)
+		**out = **in
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]PrometheusRemoteWrite, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuleFiles != nil {
+		in, out := &in.RuleFiles, &out.RuleFiles
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	in.Operator.DeepCopyInto(&out.Operator)
+	in.Alerts.DeepCopyInto(&out.Alerts)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusConfiguration.
+func (in *PrometheusConfiguration) DeepCopy() *PrometheusConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusCredentials) DeepCopyInto(out *PrometheusCredentials) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusCredentials.
+func (in *PrometheusCredentials) DeepCopy() *PrometheusCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusOperatorConfiguration) DeepCopyInto(out *PrometheusOperatorConfiguration) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusOperatorConfiguration.
+func (in *PrometheusOperatorConfiguration) DeepCopy() *PrometheusOperatorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusOperatorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusPersistence) DeepCopyInto(out *PrometheusPersistence) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusPersistence.
+func (in *PrometheusPersistence) DeepCopy() *PrometheusPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRemoteWrite) DeepCopyInto(out *PrometheusRemoteWrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRemoteWrite.
+func (in *PrometheusRemoteWrite) DeepCopy() *PrometheusRemoteWrite {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRemoteWrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfiguration) DeepCopyInto(out *ProxyConfiguration) {
+	*out = *in
+	if in.AutoInjectionNamespaces != nil {
+		in, out := &in.AutoInjectionNamespaces, &out.AutoInjectionNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Restarts.DeepCopyInto(&out.Restarts)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfiguration.
+func (in *ProxyConfiguration) DeepCopy() *ProxyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyInitConfiguration) DeepCopyInto(out *ProxyInitConfiguration) {
+	*out = *in
+	if in.IgnoreInboundPorts != nil {
+		in, out := &in.IgnoreInboundPorts, &out.IgnoreInboundPorts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreOutboundPorts != nil {
+		in, out := &in.IgnoreOutboundPorts, &out.IgnoreOutboundPorts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipSubnets != nil {
+		in, out := &in.SkipSubnets, &out.SkipSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyInitConfiguration.
+func (in *ProxyInitConfiguration) DeepCopy() *ProxyInitConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyInitConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyInjectorWebhook) DeepCopyInto(out *ProxyInjectorWebhook) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyInjectorWebhook.
+func (in *ProxyInjectorWebhook) DeepCopy() *ProxyInjectorWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyInjectorWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartsConfiguration) DeepCopyInto(out *RestartsConfiguration) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BatchInterval != nil {
+		in, out := &in.BatchInterval, &out.BatchInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartsConfiguration.
+func (in *RestartsConfiguration) DeepCopy() *RestartsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPValidatorWebhook) DeepCopyInto(out *SPValidatorWebhook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPValidatorWebhook.
+func (in *SPValidatorWebhook) DeepCopy() *SPValidatorWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(SPValidatorWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMirrorConfiguration) DeepCopyInto(out *ServiceMirrorConfiguration) {
+	*out = *in
+	if in.EventRequeueLimit != nil {
+		in, out := &in.EventRequeueLimit, &out.EventRequeueLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMirrorConfiguration.
+func (in *ServiceMirrorConfiguration) DeepCopy() *ServiceMirrorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMirrorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfiguration) DeepCopyInto(out *TracingConfiguration) {
+	*out = *in
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfiguration.
+func (in *TracingConfiguration) DeepCopy() *TracingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustAnchorsReference) DeepCopyInto(out *TrustAnchorsReference) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustAnchorsReference.
+func (in *TrustAnchorsReference) DeepCopy() *TrustAnchorsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustAnchorsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeConfiguration) DeepCopyInto(out *UpgradeConfiguration) {
+	*out = *in
+	if in.ComponentTimeout != nil {
+		in, out := &in.ComponentTimeout, &out.ComponentTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeConfiguration.
+func (in *UpgradeConfiguration) DeepCopy() *UpgradeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.UpgradedComponents != nil {
+		in, out := &in.UpgradedComponents, &out.UpgradedComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentComponentStartTime != nil {
+		in, out := &in.CurrentComponentStartTime, &out.CurrentComponentStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/cni"
+	linkerdcontroller "github.com/spaghettifunk/linkerd2-operator/pkg/resources/controller"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/destination"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/grafana"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/heartbeat"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/identity"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/multicluster"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/prometheus"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/proxyinjector"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/psp"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/serviceprofile"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/spvalidator"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/tap"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/tracing"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/trafficsplit"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/web"
+)
+
+// component is a part of the control plane reconciled as a whole
+type component struct {
+	name string
+	// deployments gate the upgrade of the next component until they are rolled out
+	deployments []string
+	reconciler  func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler
+}
+
+// components returns the components of the control plane in installation order
+func (r *ReconcileLinkerd) components() []component {
+	return []component{
+		{
+			name: "cni",
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return cni.New(r.Client, r.APIReader, r.RESTMapper, config)
+			},
+		},
+		{
+			name:        "controller",
+			deployments: []string{"linkerd-controller"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return linkerdcontroller.New(r.Client, config)
+			},
+		},
+		{
+			name:        "destination",
+			deployments: []string{"linkerd-destination"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return destination.New(r.Client, config)
+			},
+		},
+		{
+			name: "heartbeat",
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return heartbeat.New(r.Client, config)
+			},
+		},
+		{
+			name:        "identity",
+			deployments: []string{"linkerd-identity"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return identity.New(r.Client, config)
+			},
+		},
+		{
+			name:        "prometheus",
+			deployments: []string{"linkerd-prometheus"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return prometheus.New(r.Client, r.RESTMapper, config)
+			},
+		},
+		{
+			name:        "grafana",
+			deployments: []string{"linkerd-grafana"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return grafana.New(r.Client, config)
+			},
+		},
+		{
+			name:        "proxy-injector",
+			deployments: []string{"linkerd-proxy-injector"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return proxyinjector.New(r.Client, r.RESTMapper, config)
+			},
+		},
+		{
+			name: "serviceprofile",
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return serviceprofile.New(r.Client, config)
+			},
+		},
+		{
+			name:        "sp-validator",
+			deployments: []string{"linkerd-sp-validator"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return spvalidator.New(r.Client, config)
+			},
+		},
+		{
+			name: "trafficsplit",
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return trafficsplit.New(r.Client, config)
+			},
+		},
+		{
+			name:        "web",
+			deployments: []string{"linkerd-web"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return web.New(r.Client, config)
+			},
+		},
+		{
+			name:        "tap",
+			deployments: []string{"linkerd-tap"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return tap.New(r.Client, config)
+			},
+		},
+		{
+			name:        "tracing",
+			deployments: []string{"linkerd-collector", "linkerd-jaeger"},
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return tracing.New(r.Client, config)
+			},
+		},
+		{
+			// the gateway runs in the multicluster namespace, its rollout does not gate the upgrades
+			name: "multicluster",
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return multicluster.New(r.Client, config)
+			},
+		},
+		{
+			name: "psp",
+			reconciler: func(config *linkerdv1alpha1.Linkerd) resources.ComponentReconciler {
+				return psp.New(r.Client, r.RESTMapper, config)
+			},
+		},
+	}
+}
+
+// reconcileComponents reconciles the given components, in order, with the config
+func (r *ReconcileLinkerd) reconcileComponents(logger logr.Logger, components []component, config *linkerdv1alpha1.Linkerd) error {
+	for _, c := range components {
+		if err := c.reconciler(config).Reconcile(logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
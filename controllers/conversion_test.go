@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	linkerdv1alpha2 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha2"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/certs"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+var _ = Describe("Linkerd conversion", func() {
+	var conversionEnv *envtest.Environment
+	var conversionClient client.Client
+	var certDir string
+	var stop chan struct{}
+
+	BeforeEach(func() {
+		By("serving the conversion webhook")
+		serving, err := certs.GenerateServingCertificate("localhost")
+		Expect(err).NotTo(HaveOccurred())
+		certDir, err = ioutil.TempDir("", "linkerd-conversion")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(filepath.Join(certDir, "tls.crt"), []byte(serving.CrtPEM), 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(certDir, "tls.key"), []byte(serving.KeyPEM), 0600)).To(Succeed())
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		port := listener.Addr().(*net.TCPAddr).Port
+		Expect(listener.Close()).To(Succeed())
+
+		conversionScheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(conversionScheme)).To(Succeed())
+		Expect(apiextensionsv1beta1.AddToScheme(conversionScheme)).To(Succeed())
+		Expect(linkerdv1alpha1.AddToScheme(conversionScheme)).To(Succeed())
+		Expect(linkerdv1alpha2.AddToScheme(conversionScheme)).To(Succeed())
+
+		conversionEnv = &envtest.Environment{
+			CRDDirectoryPaths: []string{filepath.Join("..", "config", "crd", "bases")},
+		}
+		conversionCfg, err := conversionEnv.Start()
+		Expect(err).NotTo(HaveOccurred())
+		conversionClient, err = client.New(conversionCfg, client.Options{Scheme: conversionScheme})
+		Expect(err).NotTo(HaveOccurred())
+
+		mgr, err := ctrl.NewManager(conversionCfg, ctrl.Options{
+			Scheme:             conversionScheme,
+			MetricsBindAddress: "0",
+			Port:               port,
+			CertDir:            certDir,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect((&linkerdv1alpha2.Linkerd{}).SetupWebhookWithManager(mgr)).To(Succeed())
+		stop = make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(mgr.Start(stop)).To(Succeed())
+		}()
+
+		// config/crd/patches points the CRD at the webhook service, envtest reaches the webhook locally
+		crd := &apiextensionsv1beta1.CustomResourceDefinition{}
+		Expect(conversionClient.Get(context.Background(), types.NamespacedName{Name: "linkerds.linkerd.linkerd.io"}, crd)).To(Succeed())
+		crd.Spec.Conversion = &apiextensionsv1beta1.CustomResourceConversion{
+			Strategy: apiextensionsv1beta1.WebhookConverter,
+			WebhookClientConfig: &apiextensionsv1beta1.WebhookClientConfig{
+				URL:      util.StrPointer(fmt.Sprintf("https://localhost:%d/convert", port)),
+				CABundle: []byte(serving.TrustAnchorsPEM),
+			},
+			ConversionReviewVersions: []string{"v1beta1"},
+		}
+		Expect(conversionClient.Update(context.Background(), crd)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		close(stop)
+		Expect(conversionEnv.Stop()).To(Succeed())
+		Expect(os.RemoveAll(certDir)).To(Succeed())
+	})
+
+	It("round trips v1alpha1 resources through v1alpha2", func() {
+		ctx := context.Background()
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-conversion"}}
+		Expect(conversionClient.Create(ctx, ns)).To(Succeed())
+
+		stored := &linkerdv1alpha1.Linkerd{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: ns.Name},
+			Spec: linkerdv1alpha1.LinkerdSpec{
+				Version:  "stable-2.8.1",
+				Revision: "blue",
+				// not moved to the certificates Secret yet
+				SelfSignedCertificates: &linkerdv1alpha1.SelfSignedCertificates{
+					TrustAnchorsPEM: "anchors",
+					KeyPEM:          "key",
+					CrtPEM:          "crt",
+				},
+				AutoInjectionNamespaces: []string{"emojivoto"},
+				ProxyInit: linkerdv1alpha1.ProxyInitConfiguration{
+					IgnoreInboundPorts: []string{"5432"},
+					IptablesMode:       linkerdv1alpha1.IptablesModeNft,
+				},
+				DataPlane: linkerdv1alpha1.DataPlaneConfiguration{
+					AutoRestart: true,
+					MaintenanceWindow: &linkerdv1alpha1.MaintenanceWindow{
+						Start:    "02:00",
+						Duration: metav1.Duration{Duration: time.Hour},
+						Days:     []linkerdv1alpha1.Weekday{"Sunday"},
+					},
+				},
+				Identity: linkerdv1alpha1.IdentityConfiguration{
+					Issuer:             linkerdv1alpha1.IssuerConfiguration{ExternalSecret: "linkerd-identity-issuer"},
+					CertificatesSecret: "linkerd-self-signed-certs",
+				},
+				Prometheus: linkerdv1alpha1.PrometheusConfiguration{
+					BaseK8sResourceConfiguration: linkerdv1alpha1.BaseK8sResourceConfiguration{ReplicaCount: util.IntPointer(2)},
+					URL:                          "http://prometheus.monitoring:9090",
+					Credentials:                  &linkerdv1alpha1.PrometheusCredentials{SecretName: "prometheus", Type: linkerdv1alpha1.PrometheusAuthBasic},
+				},
+				Tap: linkerdv1alpha1.TapConfiguration{
+					BaseK8sResourceConfiguration: linkerdv1alpha1.BaseK8sResourceConfiguration{
+						NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+					},
+				},
+				Tracing: linkerdv1alpha1.TracingConfiguration{
+					Enabled:   true,
+					Collector: linkerdv1alpha1.CollectorConfiguration{SamplingPercentage: util.IntPointer(50)},
+				},
+			},
+		}
+		Expect(conversionClient.Create(ctx, stored)).To(Succeed())
+		key := types.NamespacedName{Namespace: ns.Name, Name: stored.Name}
+
+		By("reading it as v1alpha2")
+		converted := &linkerdv1alpha2.Linkerd{}
+		Eventually(func() error {
+			return conversionClient.Get(ctx, key, converted)
+		}, 30*time.Second, time.Second).Should(Succeed())
+		Expect(converted.Spec.Components).To(HaveKey(linkerdv1alpha2.ComponentTap))
+		Expect(converted.Spec.Components[linkerdv1alpha2.ComponentPrometheus].ReplicaCount).To(Equal(util.IntPointer(2)))
+		Expect(converted.Spec.Proxy.AutoInjectionNamespaces).To(Equal([]string{"emojivoto"}))
+		Expect(converted.Spec.AddOns.Prometheus.Credentials.SecretRef.Name).To(Equal("prometheus"))
+		Expect(converted.Spec.Identity.CertificatesSecret).To(Equal("linkerd-self-signed-certs"))
+		Expect(converted.Annotations).To(HaveKey(linkerdv1alpha2.CertificatesAnnotation))
+
+		By("writing it back as v1alpha2")
+		converted.Spec.LogLevel = "debug"
+		Expect(conversionClient.Update(ctx, converted)).To(Succeed())
+
+		roundTripped := &linkerdv1alpha1.Linkerd{}
+		Expect(conversionClient.Get(ctx, key, roundTripped)).To(Succeed())
+		Expect(roundTripped.Annotations).NotTo(HaveKey(linkerdv1alpha2.CertificatesAnnotation))
+		stored.Spec.LogLevel = "debug"
+		Expect(equality.Semantic.DeepEqual(roundTripped.Spec, stored.Spec)).To(BeTrue(), "the v1alpha1 spec changed:\n%v\n%v", roundTripped.Spec, stored.Spec)
+	})
+
+	It("round trips v1alpha2 resources through the v1alpha1 storage version", func() {
+		ctx := context.Background()
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-conversion-v1alpha2"}}
+		Expect(conversionClient.Create(ctx, ns)).To(Succeed())
+
+		created := &linkerdv1alpha2.Linkerd{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: ns.Name},
+			Spec: linkerdv1alpha2.LinkerdSpec{
+				Version: "stable-2.8.1",
+				Components: map[string]linkerdv1alpha2.ComponentConfiguration{
+					linkerdv1alpha2.ComponentWeb: {ReplicaCount: util.IntPointer(3)},
+				},
+				Networking: linkerdv1alpha2.NetworkingConfiguration{
+					CNI: linkerdv1alpha2.CNIConfiguration{Enabled: true},
+				},
+			},
+		}
+		Eventually(func() error {
+			return conversionClient.Create(ctx, created.DeepCopy())
+		}, 30*time.Second, time.Second).Should(Succeed())
+		key := types.NamespacedName{Namespace: ns.Name, Name: created.Name}
+
+		stored := &linkerdv1alpha1.Linkerd{}
+		Expect(conversionClient.Get(ctx, key, stored)).To(Succeed())
+		Expect(stored.Spec.Web.ReplicaCount).To(Equal(util.IntPointer(3)))
+		Expect(stored.Spec.CNI.Enabled).To(BeTrue())
+
+		roundTripped := &linkerdv1alpha2.Linkerd{}
+		Expect(conversionClient.Get(ctx, key, roundTripped)).To(Succeed())
+		Expect(equality.Semantic.DeepEqual(roundTripped.Spec, created.Spec)).To(BeTrue(), "the v1alpha2 spec changed:\n%v\n%v", roundTripped.Spec, created.Spec)
+	})
+})
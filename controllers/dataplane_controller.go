@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/dataplane"
+)
+
+// DataPlaneReconciler restarts the meshed workloads once the control plane runs the intended version
+type DataPlaneReconciler struct {
+	client.Client
+	// APIReader lists the pods of every namespace without caching them in the operator
+	APIReader client.Reader
+	Log       logr.Logger
+}
+
+// +kubebuilder:rbac:groups="",resources=pods;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;statefulsets,verbs=get;list;watch;patch
+
+// Reconcile restarts the next batch of workloads running an outdated proxy, or no proxy in an injected namespace
+func (r *DataPlaneReconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := r.Log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	config := &linkerdv1alpha1.Linkerd{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, config)
+	if err != nil {
+		if k8errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !config.Spec.DataPlane.AutoRestart {
+		return reconcile.Result{}, nil
+	}
+	// the proxies are only restarted once every component of the control plane runs the intended version
+	if config.Status.Version != config.Spec.Version {
+		return reconcile.Result{}, nil
+	}
+	release, ok := catalog.Lookup(string(config.Spec.Version))
+	if !ok {
+		return reconcile.Result{}, nil
+	}
+	linkerdv1alpha1.SetDefaults(config, release)
+
+	status, next, err := dataplane.New(r.Client, r.APIReader, config).Run(logger, time.Now())
+	if err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not restart the data plane")
+	}
+
+	original := config.DeepCopy()
+	config.Status.DataPlane = status
+	if err := r.Client.Status().Patch(context.TODO(), config, client.MergeFrom(original)); err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not update data plane status")
+	}
+
+	return reconcile.Result{RequeueAfter: next}, nil
+}
+
+// SetupWithManager sets the reconciler with the manager
+func (r *DataPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("dataplane").
+		For(&linkerdv1alpha1.Linkerd{}).
+		Complete(r)
+}
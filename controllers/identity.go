@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/identity"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+)
+
+// certificatesSecretName is the name of the Secret holding the self signed certificates of the Linkerd resource
+func certificatesSecretName(config *linkerdv1alpha1.Linkerd) string {
+	return config.Name + "-self-signed-certs"
+}
+
+// setSelfSignedCertificates sets the self signed certificates from the certificates Secret. The certificates
+// of the spec, or new ones when there are none, are first moved to the Secret, so that the Linkerd resource
+// does not expose the keys
+func (r *ReconcileLinkerd) setSelfSignedCertificates(logger logr.Logger, config *linkerdv1alpha1.Linkerd) error {
+	// the shared trust anchors replace the self signed certificates
+	if config.Spec.Identity.TrustAnchors != nil {
+		return nil
+	}
+
+	if name := config.Spec.Identity.CertificatesSecret; name != "" && config.Spec.SelfSignedCertificates == nil {
+		secret := &corev1.Secret{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: config.Namespace, Name: name}, secret); err != nil {
+			return emperror.WrapWith(err, "could not get the self signed certificates", "secret", name)
+		}
+		config.Spec.SelfSignedCertificates = &linkerdv1alpha1.SelfSignedCertificates{
+			TrustAnchorsPEM: string(secret.Data[resources.ServingCertificateCAKey]),
+			CrtPEM:          string(secret.Data[resources.ServingCertificateCrtKey]),
+			KeyPEM:          string(secret.Data[resources.ServingCertificateKeyKey]),
+		}
+		return nil
+	}
+
+	certificates := config.Spec.SelfSignedCertificates
+	if certificates == nil {
+		generated, err := linkerdv1alpha1.GenerateSelfSignedCertificates()
+		if err != nil {
+			return emperror.Wrap(err, "could not generate the self signed certificates")
+		}
+		certificates = generated
+	}
+	if err := k8sutil.Reconcile(logger, r.Client, certificatesSecret(config, certificates), k8sutil.DesiredStatePresent); err != nil {
+		return emperror.Wrap(err, "could not store the self signed certificates")
+	}
+
+	typeMeta, status := config.TypeMeta, config.Status
+	config.Spec.Identity.CertificatesSecret = certificatesSecretName(config)
+	config.Spec.SelfSignedCertificates = nil
+	if err := r.Client.Update(context.TODO(), config); err != nil {
+		return emperror.Wrap(err, "could not reference the self signed certificates Secret")
+	}
+	// update loses the typeMeta of the config that's used later when setting ownerrefs, and the conditions
+	// set so far
+	config.TypeMeta, config.Status = typeMeta, status
+	config.Spec.SelfSignedCertificates = certificates
+	logger.Info("self signed certificates moved to their Secret", "secret", config.Spec.Identity.CertificatesSecret)
+	return nil
+}
+
+// certificatesSecret returns the Secret holding the self signed certificates, removed along with the Linkerd resource
+func certificatesSecret(config *linkerdv1alpha1.Linkerd, certificates *linkerdv1alpha1.SelfSignedCertificates) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: templates.ObjectMeta(certificatesSecretName(config), nil, config),
+		Data: map[string][]byte{
+			resources.ServingCertificateCAKey:  []byte(certificates.TrustAnchorsPEM),
+			resources.ServingCertificateCrtKey: []byte(certificates.CrtPEM),
+			resources.ServingCertificateKeyKey: []byte(certificates.KeyPEM),
+		},
+	}
+}
+
+// setSharedCertificates replaces the self signed certificates with the trust anchors shared with the other
+// clusters and the issuer of this cluster, when the identity configuration references them
+func (r *ReconcileLinkerd) setSharedCertificates(config *linkerdv1alpha1.Linkerd) error {
+	shared, err := identity.SharedCertificates(r.Client, config)
+	if err != nil {
+		config.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+			Type:    linkerdv1alpha1.ConditionIdentityIssuerVerified,
+			Status:  corev1.ConditionFalse,
+			Reason:  "UntrustedIssuer",
+			Message: err.Error(),
+		})
+		return err
+	}
+	if shared == nil {
+		return nil
+	}
+
+	config.Spec.SelfSignedCertificates = shared
+	config.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+		Type:    linkerdv1alpha1.ConditionIdentityIssuerVerified,
+		Status:  corev1.ConditionTrue,
+		Reason:  "ChainVerified",
+		Message: "the identity issuer chains to the shared trust anchors",
+	})
+	return nil
+}
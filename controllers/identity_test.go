@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+var _ = Describe("Linkerd self signed certificates", func() {
+	It("moves the certificates of the spec to their Secret", func() {
+		ctx := context.Background()
+		r := &ReconcileLinkerd{Client: k8sClient, APIReader: k8sClient}
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-certificates"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		certificates, err := linkerdv1alpha1.GenerateSelfSignedCertificates()
+		Expect(err).NotTo(HaveOccurred())
+		config := &linkerdv1alpha1.Linkerd{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: ns.Name},
+			Spec: linkerdv1alpha1.LinkerdSpec{
+				Version:                "stable-2.8.1",
+				SelfSignedCertificates: certificates,
+			},
+		}
+		Expect(k8sClient.Create(ctx, config)).To(Succeed())
+		config.SetGroupVersionKind(linkerdv1alpha1.GroupVersion.WithKind("Linkerd"))
+
+		Expect(r.setSelfSignedCertificates(logf.Log, config)).To(Succeed())
+		Expect(config.Spec.SelfSignedCertificates).To(Equal(certificates), "the reconcile goes on with the certificates")
+
+		key := types.NamespacedName{Namespace: ns.Name, Name: config.Name}
+		stored := &linkerdv1alpha1.Linkerd{}
+		Expect(k8sClient.Get(ctx, key, stored)).To(Succeed())
+		Expect(stored.Spec.SelfSignedCertificates).To(BeNil(), "the keys are not exposed by the Linkerd resource")
+		Expect(stored.Spec.Identity.CertificatesSecret).To(Equal("linkerd-self-signed-certs"))
+
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: ns.Name, Name: stored.Spec.Identity.CertificatesSecret}, secret)).To(Succeed())
+		Expect(secret.OwnerReferences).To(HaveLen(1))
+
+		By("reading them back on the next reconcile")
+		Expect(r.setSelfSignedCertificates(logf.Log, stored)).To(Succeed())
+		Expect(stored.Spec.SelfSignedCertificates).To(Equal(certificates))
+	})
+})
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/servicemirror"
+)
+
+// linkProbePeriod is how often the gateway of the remote cluster of a Link is probed
+const linkProbePeriod = 30 * time.Second
+
+// LinkReconciler runs a service mirror per Link and probes the gateway of the remote cluster
+type LinkReconciler struct {
+	client.Client
+	Log logr.Logger
+	// RemoteClient returns a client of the remote cluster of a kubeconfig
+	RemoteClient func(kubeconfig []byte) (client.Client, error)
+	// Deployments are the sources of the Deployments reconciled by the operator
+	Deployments []source.Source
+}
+
+// +kubebuilder:rbac:groups=linkerd.linkerd.io,resources=links,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=linkerd.linkerd.io,resources=links/status,verbs=get;update;patch
+
+// Reconcile reconciles the service mirror of the Link and records the state of the remote gateway
+func (r *LinkReconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	logger := r.Log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	link := &linkerdv1alpha1.Link{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, link)
+	if err != nil {
+		if k8errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	// the type meta is needed to own the objects of the service mirror
+	link.SetGroupVersionKind(linkerdv1alpha1.GroupVersion.WithKind("Link"))
+	linkerdv1alpha1.SetLinkDefaults(link)
+	original := link.DeepCopy()
+
+	if err := r.reconcile(logger, link); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.Client.Status().Patch(context.TODO(), link, client.MergeFrom(original)); err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not update link status")
+	}
+	return reconcile.Result{RequeueAfter: linkProbePeriod}, nil
+}
+
+// reconcile records the outcome of each step in the conditions of the Link, the steps failing
+// because of the cluster state are retried with the next probe
+func (r *LinkReconciler) reconcile(logger logr.Logger, link *linkerdv1alpha1.Link) error {
+	config, err := r.controlPlane(link)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		link.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+			Type:    linkerdv1alpha1.ConditionControlPlaneFound,
+			Status:  corev1.ConditionFalse,
+			Reason:  "NotFound",
+			Message: fmt.Sprintf("no Linkerd resource enables multicluster in the %s namespace", link.Namespace),
+		})
+		return nil
+	}
+	link.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+		Type:    linkerdv1alpha1.ConditionControlPlaneFound,
+		Status:  corev1.ConditionTrue,
+		Reason:  "Found",
+		Message: fmt.Sprintf("the link is served by %s/%s", config.Namespace, config.Name),
+	})
+
+	secret := &corev1.Secret{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: link.Namespace, Name: link.Spec.ClusterCredentialsSecret}, secret)
+	if err != nil && !k8errors.IsNotFound(err) {
+		return emperror.WrapWith(err, "could not get the cluster credentials", "secret", link.Spec.ClusterCredentialsSecret)
+	}
+	kubeconfig := secret.Data[servicemirror.KubeconfigKey]
+	if len(kubeconfig) == 0 {
+		r.setUnreachable(link, "CredentialsNotFound", fmt.Sprintf("the secret %s has no %s key", link.Spec.ClusterCredentialsSecret, servicemirror.KubeconfigKey))
+		return nil
+	}
+
+	if err := servicemirror.New(r.Client, config, link, kubeconfig).Reconcile(logger); err != nil {
+		return emperror.Wrap(err, "could not reconcile the service mirror")
+	}
+
+	remote, err := r.RemoteClient(kubeconfig)
+	if err != nil {
+		r.setUnreachable(link, "InvalidCredentials", err.Error())
+		return nil
+	}
+	gateway, err := gatewayStatus(remote, types.NamespacedName{Namespace: link.Spec.GatewayNamespace, Name: link.Spec.GatewayName})
+	if err != nil {
+		r.setUnreachable(link, "Unreachable", err.Error())
+		return nil
+	}
+
+	now := metav1.Now()
+	link.Status.LastProbeTime = &now
+	link.Status.Gateway = gateway
+	link.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+		Type:   linkerdv1alpha1.ConditionRemoteClusterReachable,
+		Status: corev1.ConditionTrue,
+		Reason: "Reachable",
+	})
+	return nil
+}
+
+// controlPlane returns the defaulted Linkerd resource with multicluster enabled in the namespace of the Link
+func (r *LinkReconciler) controlPlane(link *linkerdv1alpha1.Link) (*linkerdv1alpha1.Linkerd, error) {
+	linkerds := &linkerdv1alpha1.LinkerdList{}
+	if err := r.Client.List(context.TODO(), linkerds); err != nil {
+		return nil, emperror.Wrap(err, "could not list Linkerd resources")
+	}
+	for i := range linkerds.Items {
+		config := &linkerds.Items[i]
+		release, ok := catalog.Lookup(string(config.Spec.Version))
+		if !ok {
+			continue
+		}
+		linkerdv1alpha1.SetDefaults(config, release)
+		if config.Spec.Multicluster.Enabled && config.Spec.Multicluster.Namespace == link.Namespace {
+			return config, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *LinkReconciler) setUnreachable(link *linkerdv1alpha1.Link, reason, message string) {
+	link.Status.Gateway = nil
+	link.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+		Type:    linkerdv1alpha1.ConditionRemoteClusterReachable,
+		Status:  corev1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// SetupWithManager sets the reconciler with the manager
+func (r *LinkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named("link").
+		For(&linkerdv1alpha1.Link{})
+	for _, deployments := range r.Deployments {
+		builder = builder.Watches(deployments, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &linkerdv1alpha1.Link{},
+		})
+	}
+	return builder.Complete(r)
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/servicemirror"
+)
+
+var _ = Describe("multicluster Links", func() {
+	var remoteEnv *envtest.Environment
+	var remoteClient client.Client
+	var kubeconfig []byte
+
+	BeforeEach(func() {
+		By("bootstrapping the remote cluster")
+		remoteEnv = &envtest.Environment{}
+		remoteCfg, err := remoteEnv.Start()
+		Expect(err).NotTo(HaveOccurred())
+		remoteClient, err = client.New(remoteCfg, client.Options{Scheme: scheme.Scheme})
+		Expect(err).NotTo(HaveOccurred())
+
+		kubeconfig, err = clientcmd.Write(clientcmdapi.Config{
+			Clusters: map[string]*clientcmdapi.Cluster{
+				"remote": {Server: remoteCfg.Host},
+			},
+			Contexts: map[string]*clientcmdapi.Context{
+				"remote": {Cluster: "remote"},
+			},
+			CurrentContext: "remote",
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(remoteEnv.Stop()).To(Succeed())
+	})
+
+	It("runs a service mirror and records the state of the remote gateway", func() {
+		ctx := context.Background()
+
+		By("running a gateway in the remote cluster")
+		remoteNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-multicluster"}}
+		Expect(remoteClient.Create(ctx, remoteNs)).To(Succeed())
+		gateway := types.NamespacedName{Namespace: remoteNs.Name, Name: "linkerd-gateway"}
+		Expect(remoteClient.Create(ctx, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: gateway.Name, Namespace: gateway.Namespace},
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeClusterIP,
+				Ports: []corev1.ServicePort{{Name: "incoming-port", Port: 4143}},
+			},
+		})).To(Succeed())
+		Expect(remoteClient.Create(ctx, &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: gateway.Name, Namespace: gateway.Namespace},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+				Ports:     []corev1.EndpointPort{{Name: "incoming-port", Port: 4143}},
+			}},
+		})).To(Succeed())
+
+		By("linking the local control plane to the remote cluster")
+		controlPlaneNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-link"}}
+		Expect(k8sClient.Create(ctx, controlPlaneNs)).To(Succeed())
+		multiclusterNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-link-multicluster"}}
+		Expect(k8sClient.Create(ctx, multiclusterNs)).To(Succeed())
+		Expect(k8sClient.Create(ctx, &linkerdv1alpha1.Linkerd{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: controlPlaneNs.Name},
+			Spec: linkerdv1alpha1.LinkerdSpec{
+				Version: "stable-2.8.1",
+				Multicluster: linkerdv1alpha1.MulticlusterConfiguration{
+					Enabled:   true,
+					Namespace: multiclusterNs.Name,
+				},
+			},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote-kubeconfig", Namespace: multiclusterNs.Name},
+			Data:       map[string][]byte{servicemirror.KubeconfigKey: kubeconfig},
+		})).To(Succeed())
+		link := &linkerdv1alpha1.Link{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: multiclusterNs.Name},
+			Spec: linkerdv1alpha1.LinkSpec{
+				TargetClusterName:        "remote",
+				ClusterCredentialsSecret: "remote-kubeconfig",
+			},
+		}
+		Expect(k8sClient.Create(ctx, link)).To(Succeed())
+
+		reconciler := &LinkReconciler{
+			Client: k8sClient,
+			Log:    logf.Log,
+			RemoteClient: func(kubeconfig []byte) (client.Client, error) {
+				return k8sutil.ClientFromKubeconfig(kubeconfig, scheme.Scheme)
+			},
+		}
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: link.Namespace, Name: link.Name}}
+		result, err := reconciler.Reconcile(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(linkProbePeriod))
+
+		By("checking the service mirror")
+		deployment := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: link.Namespace, Name: servicemirror.DeploymentName(link)}, deployment)).To(Succeed())
+		Expect(deployment.OwnerReferences).To(HaveLen(1))
+		Expect(deployment.OwnerReferences[0].Kind).To(Equal("Link"))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("remote"))
+		credentials := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: link.Namespace, Name: "cluster-credentials-remote"}, credentials)).To(Succeed())
+		Expect(credentials.Data).To(HaveKeyWithValue(servicemirror.KubeconfigKey, kubeconfig))
+
+		By("checking the status of the link")
+		Expect(k8sClient.Get(ctx, request.NamespacedName, link)).To(Succeed())
+		Expect(link.Status.LastProbeTime).NotTo(BeNil())
+		Expect(link.Status.Gateway).NotTo(BeNil())
+		Expect(link.Status.Gateway.Alive).To(BeTrue())
+		for _, condition := range link.Status.Conditions {
+			Expect(condition.Status).To(Equal(corev1.ConditionTrue), string(condition.Type))
+		}
+
+		By("stopping the remote gateway")
+		Expect(remoteClient.Delete(ctx, &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: gateway.Name, Namespace: gateway.Namespace},
+		})).To(Succeed())
+		_, err = reconciler.Reconcile(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, request.NamespacedName, link)).To(Succeed())
+		Expect(link.Status.Gateway.Alive).To(BeFalse())
+	})
+})
@@ -18,24 +18,18 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
 	"github.com/pkg/errors"
 	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
-	linkerdcontroller "github.com/spaghettifunk/linkerd2-operator/pkg/resources/controller"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/destination"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/heartbeat"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/identity"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/prometheus"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/proxyinjector"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/psp"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/tap"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/web"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -62,7 +56,7 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileLinkerd{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}
+	return &ReconcileLinkerd{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), RESTMapper: mgr.GetRESTMapper()}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -97,14 +91,30 @@ type ReconcileLinkerd struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	// APIReader lists the Linkerd resources of every namespace, the cache only holds the watched ones
+	APIReader client.Reader
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	// RESTMapper is used to discover the APIs served by the cluster
+	RESTMapper meta.RESTMapper
+	// ReleasesConfigMap holds the Linkerd releases added to the catalog built into the operator
+	ReleasesConfigMap types.NamespacedName
+	// Deployments are the sources of the Deployments reconciled by the operator
+	Deployments []source.Source
 }
 
-// Reconcile reads that state of the cluster for a Linkerd object and makes changes based on the state read
-// and what is in the Linkerd.Spec
 // +kubebuilder:rbac:groups=linkerd.linkerd.io,resources=linkerds,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=linkerd.linkerd.io,resources=linkerds/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind;escalate
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apiregistration.k8s.io,resources=apiservices,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=podsecuritypolicies,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile reads that state of the cluster for a Linkerd object and makes changes based on the state read
+// and what is in the Linkerd.Spec
 func (r *ReconcileLinkerd) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	logger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 
@@ -126,19 +136,99 @@ func (r *ReconcileLinkerd) Reconcile(request reconcile.Request) (reconcile.Resul
 
 	logger.Info("Reconciling Linkerd")
 
-	if !config.Spec.Version.IsSupported() {
-		err = errors.New("intended Linkerd version is unsupported by this version of the operator")
-		logger.Error(err, "", "version", config.Spec.Version)
+	release, ok := r.release(logger, config)
+	if !ok {
+		message := fmt.Sprintf("Linkerd version %q is not in the release catalog, supported versions are: %s",
+			config.Spec.Version, strings.Join(catalog.Versions(), ", "))
+		logger.Info("intended Linkerd version is unsupported by this version of the operator", "version", config.Spec.Version)
+		config.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+			Type:    linkerdv1alpha1.ConditionVersionSupported,
+			Status:  corev1.ConditionFalse,
+			Reason:  "UnknownVersion",
+			Message: message,
+		})
+		if err := updateStatus(r.Client, config, linkerdv1alpha1.ReconcileFailed, message, logger); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
 		return reconcile.Result{
 			Requeue: false,
 		}, nil
 	}
+	// the validating webhook rejects it too, but webhooks are optional
+	if message := unsupportedSettings(config, release); message != "" {
+		logger.Info("intended Linkerd version does not support the settings of the spec", "reason", message)
+		config.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+			Type:    linkerdv1alpha1.ConditionVersionSupported,
+			Status:  corev1.ConditionFalse,
+			Reason:  "UnsupportedSettings",
+			Message: message,
+		})
+		if err := updateStatus(r.Client, config, linkerdv1alpha1.ReconcileFailed, message, logger); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
+		return reconcile.Result{}, nil
+	}
+	config.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+		Type:    linkerdv1alpha1.ConditionVersionSupported,
+		Status:  corev1.ConditionTrue,
+		Reason:  "KnownVersion",
+		Message: fmt.Sprintf("Linkerd version %q is in the release catalog", config.Spec.Version),
+	})
+
+	installer, err := r.revisionInstaller(config)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if installer != nil {
+		// reconciled again by the watch once the installing Linkerd resource is deleted, or periodically when
+		// it is not watched
+		reason, message := "DuplicateRevision", fmt.Sprintf("the control plane of revision %q is already installed by Linkerd %s/%s, set another revision to install a second control plane",
+			config.Spec.Revision, installer.Namespace, installer.Name)
+		if installer.Spec.Revision != config.Spec.Revision {
+			reason, message = "SharedNamespace", fmt.Sprintf("namespace %q already runs the control plane of revision %q installed by Linkerd %s/%s, install each revision in its own namespace",
+				config.Namespace, installer.Spec.Revision, installer.Namespace, installer.Name)
+		}
+		logger.Info("the control plane is installed by another Linkerd resource", "installer", installer.Namespace+"/"+installer.Name, "reason", reason)
+		config.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+			Type:    linkerdv1alpha1.ConditionRevisionUnique,
+			Status:  corev1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		})
+		if err := updateStatus(r.Client, config, linkerdv1alpha1.ReconcileFailed, message, logger); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
+		if !r.watched(installer) {
+			return reconcile.Result{RequeueAfter: resyncPeriod}, nil
+		}
+		return reconcile.Result{}, nil
+	}
+	config.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+		Type:    linkerdv1alpha1.ConditionRevisionUnique,
+		Status:  corev1.ConditionTrue,
+		Reason:  "UniqueRevision",
+		Message: fmt.Sprintf("no other Linkerd resource installs the control plane of revision %q", config.Spec.Revision),
+	})
+
+	if err := r.setSelfSignedCertificates(logger, config); err != nil {
+		if updateErr := updateStatus(r.Client, config, linkerdv1alpha1.ReconcileFailed, err.Error(), logger); updateErr != nil {
+			logger.Error(updateErr, "failed to update state")
+		}
+		return reconcile.Result{}, err
+	}
 
 	// Set default values where not set
-	linkerdv1alpha1.SetDefaults(config)
+	raw := config.DeepCopy()
+	linkerdv1alpha1.SetDefaults(config, release)
+	if err := r.setSharedCertificates(config); err != nil {
+		if updateErr := updateStatus(r.Client, config, linkerdv1alpha1.ReconcileFailed, err.Error(), logger); updateErr != nil {
+			logger.Error(updateErr, "failed to update state")
+		}
+		return reconcile.Result{}, emperror.Wrap(err, "could not set the shared certificates")
+	}
 
 	// start reconciling loop
-	result, err := r.reconcile(logger, config)
+	result, err := r.reconcile(logger, raw, config)
 	if err != nil {
 		updateErr := updateStatus(r.Client, config, linkerdv1alpha1.ReconcileFailed, err.Error(), logger)
 		if updateErr != nil {
@@ -150,7 +240,30 @@ func (r *ReconcileLinkerd) Reconcile(request reconcile.Request) (reconcile.Resul
 	return result, nil
 }
 
-func (r *ReconcileLinkerd) reconcile(logger logr.Logger, config *linkerdv1alpha1.Linkerd) (reconcile.Result, error) {
+// release returns the catalog release of the intended Linkerd version. The catalog is first
+// extended with the releases of the ConfigMap of the operator, if any
+func (r *ReconcileLinkerd) release(logger logr.Logger, config *linkerdv1alpha1.Linkerd) (catalog.Release, bool) {
+	if r.ReleasesConfigMap.Name != "" {
+		if err := catalog.Load(r.Client, r.ReleasesConfigMap); err != nil {
+			// keep going with the releases known so far
+			logger.Error(err, "could not extend the release catalog", "configmap", r.ReleasesConfigMap)
+		}
+	}
+	return catalog.Lookup(string(config.Spec.Version))
+}
+
+// unsupportedSettings returns why the release cannot run the spec of config, empty when it can
+func unsupportedSettings(config *linkerdv1alpha1.Linkerd, release catalog.Release) string {
+	if config.Spec.ProxyInit.IptablesMode == linkerdv1alpha1.IptablesModeNft && !release.SupportsIptablesNft() {
+		return fmt.Sprintf("proxy-init %s of Linkerd %s does not support the nft iptables mode, set spec.proxyInit.iptablesMode to legacy or upgrade Linkerd",
+			release.ProxyInitVersion, release.Version)
+	}
+	return ""
+}
+
+// reconcile reconciles the components with the defaulted config. raw is the config as stored in the cluster,
+// saved once applied so that a failed upgrade rolls back to it
+func (r *ReconcileLinkerd) reconcile(logger logr.Logger, raw, config *linkerdv1alpha1.Linkerd) (reconcile.Result, error) {
 	if config.Status.Status == "" {
 		err := updateStatus(r.Client, config, linkerdv1alpha1.Created, "", logger)
 		if err != nil {
@@ -158,35 +271,37 @@ func (r *ReconcileLinkerd) reconcile(logger logr.Logger, config *linkerdv1alpha1
 		}
 	}
 
+	// a new version is rolled out one component after the other
+	if config.Status.Version != "" && config.Status.Version != config.Spec.Version {
+		return r.upgrade(logger, raw, config)
+	}
+
 	// for each component do a reconciliation
-	reconcilers := []resources.ComponentReconciler{
-		linkerdcontroller.New(r.Client, config),
-		destination.New(r.Client, config),
-		heartbeat.New(r.Client, config),
-		identity.New(r.Client, config),
-		prometheus.New(r.Client, config),
-		proxyinjector.New(r.Client, config),
-		// serviceprofile.New(r.Client, config),
-		// trafficsplit.New(r.Client, config),
-		web.New(r.Client, config),
-		tap.New(r.Client, config),
-		psp.New(r.Client, config),
+	if err := r.reconcileComponents(logger, r.components(), config); err != nil {
+		return reconcile.Result{}, err
 	}
-	for _, rec := range reconcilers {
-		err := rec.Reconcile(logger)
-		if err != nil {
-			return reconcile.Result{}, err
-		}
+	config.Status.Version = config.Spec.Version
+	if err := r.saveLastAppliedSpec(logger, raw); err != nil {
+		return reconcile.Result{}, err
+	}
+	tapAvailable, err := r.setTapAPICondition(config)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	gatewayAlive, err := r.setGatewayStatus(config)
+	if err != nil {
+		return reconcile.Result{}, err
 	}
 
-	err := updateStatus(r.Client, config, linkerdv1alpha1.Available, "", logger)
+	err = updateStatus(r.Client, config, linkerdv1alpha1.Available, "", logger)
 	if err != nil {
 		return reconcile.Result{}, errors.WithStack(err)
 	}
 
 	logger.Info("reconcile finished")
 
-	return reconcile.Result{}, nil
+	// neither the APIService nor the gateway of the multicluster namespace are watched
+	return availabilityResult(config, tapAvailable, gatewayAlive), nil
 }
 
 func updateStatus(c client.Client, config *linkerdv1alpha1.Linkerd, status linkerdv1alpha1.ConfigState, errorMessage string, logger logr.Logger) error {
@@ -215,8 +330,10 @@ func updateStatus(c client.Client, config *linkerdv1alpha1.Linkerd, status linke
 			return emperror.Wrap(err, "could not get config for updating status")
 		}
 
-		actualConfig.Status.Status = status
-		actualConfig.Status.ErrorMessage = errorMessage
+		// the data plane status is owned by the data plane controller
+		dataPlane := actualConfig.Status.DataPlane
+		actualConfig.Status = config.Status
+		actualConfig.Status.DataPlane = dataPlane
 
 		err = c.Status().Update(context.Background(), &actualConfig)
 		if k8errors.IsNotFound(err) {
@@ -259,7 +376,17 @@ func RemoveFinalizers(c client.Client) error {
 
 // SetupWithManager sets the reconciler with the manager
 func (r *ReconcileLinkerd) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&linkerdv1alpha1.Linkerd{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &linkerdv1alpha1.Linkerd{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.sameRevision),
+		})
+	// rollouts of the control plane drive the upgrades
+	for _, deployments := range r.Deployments {
+		builder = builder.Watches(deployments, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &linkerdv1alpha1.Linkerd{},
+		})
+	}
+	return builder.Complete(r)
 }
@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
+)
+
+var _ = Describe("Linkerd settings", func() {
+	It("reports the iptables mode the proxy-init of the release does not support", func() {
+		config := &linkerdv1alpha1.Linkerd{Spec: linkerdv1alpha1.LinkerdSpec{Version: "stable-2.8.1"}}
+		release, ok := catalog.Lookup(string(config.Spec.Version))
+		Expect(ok).To(BeTrue())
+		Expect(unsupportedSettings(config, release)).To(BeEmpty())
+
+		config.Spec.ProxyInit.IptablesMode = linkerdv1alpha1.IptablesModeNft
+		Expect(unsupportedSettings(config, release)).To(ContainSubstring("does not support the nft iptables mode"))
+
+		release.ProxyInitVersion = "v1.4.0"
+		Expect(unsupportedSettings(config, release)).To(BeEmpty())
+	})
+})
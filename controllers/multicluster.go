@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/multicluster"
+)
+
+// resyncPeriod is how often a control plane with multicluster or CNI enabled is reconciled once available.
+// The gateway Deployment is only watched when the multicluster namespace is in WATCH_NAMESPACE, and the
+// Linkerd resource installing CNI may be in a namespace that is not watched
+const resyncPeriod = 5 * time.Minute
+
+// availabilityResult requeues the Linkerd resource until the tap API and the gateway are available, then
+// periodically while multicluster or CNI is enabled
+func availabilityResult(config *linkerdv1alpha1.Linkerd, tapAvailable, gatewayAlive bool) reconcile.Result {
+	switch {
+	case !tapAvailable || !gatewayAlive:
+		return reconcile.Result{RequeueAfter: availabilityRequeuePeriod}
+	case config.Spec.Multicluster.Enabled || config.Spec.CNI.Enabled:
+		return reconcile.Result{RequeueAfter: resyncPeriod}
+	}
+	return reconcile.Result{}
+}
+
+// setGatewayStatus records the state of the gateway of the control plane, or clears it when
+// multicluster is disabled. It returns false while the gateway is not alive
+func (r *ReconcileLinkerd) setGatewayStatus(config *linkerdv1alpha1.Linkerd) (bool, error) {
+	if !config.Spec.Multicluster.Enabled {
+		config.Status.Multicluster = nil
+		return true, nil
+	}
+
+	status, err := gatewayStatus(r.Client, types.NamespacedName{
+		Namespace: config.Spec.Multicluster.Namespace,
+		Name:      multicluster.GatewayName,
+	})
+	if err != nil {
+		return false, err
+	}
+	config.Status.Multicluster = status
+	return status.Alive, nil
+}
+
+// gatewayStatus reads the state of a gateway from its Service and its Endpoints, whether it runs in this
+// cluster or in a linked one. Those are the only objects the remote access service account can read
+func gatewayStatus(c client.Client, gateway types.NamespacedName) (*linkerdv1alpha1.GatewayStatus, error) {
+	status := &linkerdv1alpha1.GatewayStatus{}
+
+	service := &corev1.Service{}
+	err := c.Get(context.TODO(), gateway, service)
+	switch {
+	case k8errors.IsNotFound(err):
+		status.Message = fmt.Sprintf("the gateway service %s is not found", gateway)
+		return status, nil
+	case err != nil:
+		return nil, emperror.WrapWith(err, "could not get the gateway service", "gateway", gateway)
+	}
+
+	endpoints := &corev1.Endpoints{}
+	err = c.Get(context.TODO(), gateway, endpoints)
+	if err != nil && !k8errors.IsNotFound(err) {
+		return nil, emperror.WrapWith(err, "could not get the gateway endpoints", "gateway", gateway)
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			status.Alive = true
+		}
+	}
+
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			status.Addresses = append(status.Addresses, ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			status.Addresses = append(status.Addresses, ingress.Hostname)
+		}
+	}
+
+	switch {
+	case !status.Alive:
+		status.Message = "the gateway has no ready endpoint"
+	case len(status.Addresses) == 0 && service.Spec.Type == corev1.ServiceTypeLoadBalancer:
+		status.Message = "the gateway load balancer has no address yet"
+	}
+	return status, nil
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/prometheus"
+)
+
+var _ = Describe("prometheus-operator integration", func() {
+	It("creates the PodMonitors and PrometheusRule when the CRDs are served", func() {
+		ctx := context.Background()
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-monitors"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		config := &linkerdv1alpha1.Linkerd{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: ns.Name},
+			Spec: linkerdv1alpha1.LinkerdSpec{
+				Version: "stable-2.8.1",
+				Prometheus: linkerdv1alpha1.PrometheusConfiguration{
+					URL: "http://prometheus-operated.monitoring:9090",
+					Operator: linkerdv1alpha1.PrometheusOperatorConfiguration{
+						Enabled: true,
+						Labels:  map[string]string{"release": "monitoring"},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, config)).To(Succeed())
+		config.SetGroupVersionKind(linkerdv1alpha1.GroupVersion.WithKind("Linkerd"))
+		release, _ := catalog.Lookup("stable-2.8.1")
+		linkerdv1alpha1.SetDefaults(config, release)
+		certificates, err := linkerdv1alpha1.GenerateSelfSignedCertificates()
+		Expect(err).NotTo(HaveOccurred())
+		config.Spec.SelfSignedCertificates = certificates
+
+		mapper, err := k8sutil.NewCachedRESTMapper(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prometheus.New(k8sClient, mapper, config).Reconcile(logf.Log)).To(Succeed())
+
+		for name, port := range map[string]string{
+			"linkerd-controller": "admin-http",
+			"linkerd-proxy":      "linkerd-admin",
+		} {
+			podMonitor := &unstructured.Unstructured{}
+			podMonitor.SetAPIVersion("monitoring.coreos.com/v1")
+			podMonitor.SetKind("PodMonitor")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: ns.Name, Name: name}, podMonitor)).To(Succeed())
+			Expect(podMonitor.GetLabels()).To(HaveKeyWithValue("release", "monitoring"))
+
+			endpoints, _, _ := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints")
+			Expect(endpoints).To(HaveLen(1))
+			Expect(endpoints[0]).To(HaveKeyWithValue("port", port))
+		}
+
+		prometheusRule := &unstructured.Unstructured{}
+		prometheusRule.SetAPIVersion("monitoring.coreos.com/v1")
+		prometheusRule.SetKind("PrometheusRule")
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: ns.Name, Name: "linkerd-control-plane"}, prometheusRule)).To(Succeed())
+		groups, _, _ := unstructured.NestedSlice(prometheusRule.Object, "spec", "groups")
+		Expect(groups).NotTo(BeEmpty())
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Linkerd2 Operator authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/goph/emperror"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+// revisionInstaller returns the Linkerd resource installing the control plane of the revision of config, or
+// another control plane in the namespace of config, when it is not config. Two control planes of a revision
+// would share every cluster-scoped resource, e.g. the linkerd-controller ClusterRole and the webhook
+// configurations, and two control planes of a namespace every namespaced one, e.g. the linkerd-destination
+// Deployment, since the revision only suffixes the cluster-scoped names. Only the oldest one is reconciled
+func (r *ReconcileLinkerd) revisionInstaller(config *linkerdv1alpha1.Linkerd) (*linkerdv1alpha1.Linkerd, error) {
+	linkerds := &linkerdv1alpha1.LinkerdList{}
+	if err := r.APIReader.List(context.TODO(), linkerds); err != nil {
+		return nil, emperror.Wrap(err, "could not list Linkerd resources")
+	}
+
+	var installer *linkerdv1alpha1.Linkerd
+	for i := range linkerds.Items {
+		other := &linkerds.Items[i]
+		if other.UID == config.UID || !sharesControlPlane(other, config) || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if precedes(other, config) && (installer == nil || precedes(other, installer)) {
+			installer = other
+		}
+	}
+	return installer, nil
+}
+
+// sharesControlPlane tells whether both Linkerd resources would install the same resources, having the same
+// revision or the same namespace
+func sharesControlPlane(a, b *linkerdv1alpha1.Linkerd) bool {
+	return a.Spec.Revision == b.Spec.Revision || a.Namespace == b.Namespace
+}
+
+// watched tells whether the Linkerd resource is in the cache of the operator, so that its deletion enqueues the
+// other Linkerd resources of its revision
+func (r *ReconcileLinkerd) watched(config *linkerdv1alpha1.Linkerd) bool {
+	key := types.NamespacedName{Namespace: config.Namespace, Name: config.Name}
+	return r.Client.Get(context.TODO(), key, &linkerdv1alpha1.Linkerd{}) == nil
+}
+
+// precedes orders the Linkerd resources by creation, the oldest one installs the control plane
+func precedes(a, b *linkerdv1alpha1.Linkerd) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// sameRevision enqueues the Linkerd resources of the revision or of the namespace of the changed one, so
+// that the next one installs the control plane once the oldest one is deleted
+func (r *ReconcileLinkerd) sameRevision(obj handler.MapObject) []reconcile.Request {
+	changed, ok := obj.Object.(*linkerdv1alpha1.Linkerd)
+	if !ok {
+		return nil
+	}
+	// only the watched Linkerd resources are enqueued, the cache holds no other
+	linkerds := &linkerdv1alpha1.LinkerdList{}
+	if err := r.Client.List(context.TODO(), linkerds); err != nil {
+		log.Error(err, "could not list Linkerd resources")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, other := range linkerds.Items {
+		if other.UID != changed.UID && sharesControlPlane(&other, changed) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: other.Namespace, Name: other.Name}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+var _ = Describe("Linkerd revisions", func() {
+	It("only lets the oldest Linkerd resource of a revision or of a namespace install its control plane", func() {
+		ctx := context.Background()
+		r := &ReconcileLinkerd{Client: k8sClient, APIReader: k8sClient}
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-revisions"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		linkerd := func(name, revision string) *linkerdv1alpha1.Linkerd {
+			config := &linkerdv1alpha1.Linkerd{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns.Name},
+				Spec:       linkerdv1alpha1.LinkerdSpec{Version: "stable-2.8.1", Revision: revision},
+			}
+			Expect(k8sClient.Create(ctx, config)).To(Succeed())
+			return config
+		}
+		first := linkerd("linkerd-a", "edge")
+		second := linkerd("linkerd-b", "edge")
+		shared := linkerd("linkerd-c", "stable")
+
+		installer, err := r.revisionInstaller(first)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installer).To(BeNil())
+
+		installer, err = r.revisionInstaller(second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installer).NotTo(BeNil())
+		Expect(installer.Name).To(Equal(first.Name))
+
+		By("installing another revision in the same namespace")
+		installer, err = r.revisionInstaller(shared)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installer).NotTo(BeNil(), "both revisions would share the namespaced resources")
+		Expect(installer.Name).To(Equal(first.Name))
+
+		By("installing another revision in its own namespace")
+		otherNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-revisions-stable"}}
+		Expect(k8sClient.Create(ctx, otherNs)).To(Succeed())
+		other := &linkerdv1alpha1.Linkerd{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: otherNs.Name},
+			Spec:       linkerdv1alpha1.LinkerdSpec{Version: "stable-2.8.1", Revision: "canary"},
+		}
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+		installer, err = r.revisionInstaller(other)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installer).To(BeNil())
+
+		By("deleting the installing Linkerd resource")
+		Expect(k8sClient.Delete(ctx, first)).To(Succeed())
+		installer, err = r.revisionInstaller(second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(installer).To(BeNil())
+	})
+})
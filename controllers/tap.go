@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/tap"
+)
+
+// availabilityRequeuePeriod is how often the tap APIService and the multicluster gateway are checked
+// until they become available, the changes of their status are not watched
+const availabilityRequeuePeriod = 30 * time.Second
+
+// setTapAPICondition reports whether the API server can reach the tap API, which `linkerd tap` and the
+// dashboard depend on. It returns false while the API is not available yet
+func (r *ReconcileLinkerd) setTapAPICondition(config *linkerdv1alpha1.Linkerd) (bool, error) {
+	// the tap API is registered by the control plane without revision
+	if config.Spec.Revision != "" {
+		return true, nil
+	}
+
+	apiService := &apiregistrationv1.APIService{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: tap.APIServiceName}, apiService)
+	if k8errors.IsNotFound(err) {
+		config.Status.SetCondition(linkerdv1alpha1.LinkerdCondition{
+			Type:    linkerdv1alpha1.ConditionTapAPIAvailable,
+			Status:  corev1.ConditionFalse,
+			Reason:  "NotFound",
+			Message: "the tap APIService is not registered",
+		})
+		return false, nil
+	}
+	if err != nil {
+		return false, emperror.WrapWith(err, "could not get the tap APIService", "name", tap.APIServiceName)
+	}
+
+	condition := linkerdv1alpha1.LinkerdCondition{
+		Type:   linkerdv1alpha1.ConditionTapAPIAvailable,
+		Status: corev1.ConditionUnknown,
+		Reason: "Pending",
+	}
+	for _, c := range apiService.Status.Conditions {
+		if c.Type == apiregistrationv1.Available {
+			condition.Status = corev1.ConditionStatus(c.Status)
+			condition.Reason = c.Reason
+			condition.Message = c.Message
+		}
+	}
+	config.Status.SetCondition(condition)
+	return condition.Status == corev1.ConditionTrue, nil
+}
@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+// upgradeRequeuePeriod is how often a rollout is checked, in case no Deployment event arrives
+const upgradeRequeuePeriod = 10 * time.Second
+
+// lastAppliedKey is the key of the last applied spec in its ConfigMap
+const lastAppliedKey = "spec"
+
+// upgradeFirst are the components upgraded before the others: the proxies of the new
+// control plane need certificates from identity and endpoints from destination, and
+// the workloads restarted during the upgrade must be injected with the new proxy
+var upgradeFirst = []string{"identity", "destination", "proxy-injector"}
+
+// upgradeOrder returns the components in the order they are upgraded
+func upgradeOrder(components []component) []component {
+	ordered := make([]component, 0, len(components))
+	for _, name := range upgradeFirst {
+		for _, c := range components {
+			if c.name == name {
+				ordered = append(ordered, c)
+			}
+		}
+	}
+	for _, c := range components {
+		if !util.ContainsString(upgradeFirst, c.name) {
+			ordered = append(ordered, c)
+		}
+	}
+	return ordered
+}
+
+// upgradeStep is what the upgrade does once the rollout state of the current component is known
+type upgradeStep string
+
+const (
+	// upgradeNextComponent moves on to the next component
+	upgradeNextComponent upgradeStep = "NextComponent"
+	// upgradeWait checks the rollout of the current component again later
+	upgradeWait upgradeStep = "Wait"
+	// upgradeRollBack reverts every component to the previous version
+	upgradeRollBack upgradeStep = "RollBack"
+)
+
+// upgrade moves the control plane from the version in status to the one in spec, one component at a time.
+// The components not upgraded yet keep running the previous spec. When a component does not finish
+// rolling out within the timeout, every component goes back to the previous spec
+func (r *ReconcileLinkerd) upgrade(logger logr.Logger, raw, config *linkerdv1alpha1.Linkerd) (reconcile.Result, error) {
+	upgrade := currentUpgrade(&config.Status, config.Spec.Version, config.Generation)
+	logger = logger.WithValues("from", upgrade.FromVersion, "to", upgrade.ToVersion)
+
+	lastApplied, err := r.lastAppliedSpec(config)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	previous, err := previousConfig(raw, lastApplied, config, upgrade.FromVersion)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// stay on the previous spec until the spec changes again
+	if upgrade.Phase == linkerdv1alpha1.UpgradeRolledBack {
+		if err := r.reconcileComponents(logger, r.components(), previous); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, updateStatus(r.Client, config, linkerdv1alpha1.ReconcileFailed, upgrade.Message, logger)
+	}
+
+	components := upgradeOrder(r.components())
+	for i, c := range components {
+		if util.ContainsString(upgrade.UpgradedComponents, c.name) {
+			if err := r.reconcileComponents(logger, components[i:i+1], config); err != nil {
+				return reconcile.Result{}, err
+			}
+			continue
+		}
+
+		started := startComponent(upgrade, c.name, time.Now())
+		if started {
+			logger.Info("upgrading component", "component", c.name)
+		}
+		if err := r.reconcileComponents(logger, components[i:i+1], config); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		state := k8sutil.RolloutComplete
+		if len(c.deployments) > 0 {
+			// the Deployments were just updated, their rollout is checked on the next pass
+			state = k8sutil.RolloutProgressing
+			if !started {
+				state, err = k8sutil.DeploymentsRolloutState(r.Client, config.Namespace, c.deployments...)
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+		}
+
+		switch nextUpgradeStep(upgrade, state, config.Spec.Upgrade.ComponentTimeout.Duration, time.Now()) {
+		case upgradeRollBack:
+			return r.rollback(logger, previous, config)
+		case upgradeWait:
+			if err := r.reconcileComponents(logger, components[i+1:], previous); err != nil {
+				return reconcile.Result{}, err
+			}
+			if err := updateStatus(r.Client, config, linkerdv1alpha1.Reconciling, "", logger); err != nil {
+				return reconcile.Result{}, errors.WithStack(err)
+			}
+			return reconcile.Result{RequeueAfter: upgradeRequeuePeriod}, nil
+		}
+	}
+
+	logger.Info("upgrade finished")
+	upgrade.Phase = linkerdv1alpha1.UpgradeCompleted
+	upgrade.Message = ""
+	config.Status.Version = config.Spec.Version
+	if err := r.saveLastAppliedSpec(logger, raw); err != nil {
+		return reconcile.Result{}, err
+	}
+	tapAvailable, err := r.setTapAPICondition(config)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	gatewayAlive, err := r.setGatewayStatus(config)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := updateStatus(r.Client, config, linkerdv1alpha1.Available, "", logger); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	return availabilityResult(config, tapAvailable, gatewayAlive), nil
+}
+
+// rollback renders every component with the previous spec
+func (r *ReconcileLinkerd) rollback(logger logr.Logger, previous, config *linkerdv1alpha1.Linkerd) (reconcile.Result, error) {
+	upgrade := config.Status.Upgrade
+	logger.Info("rolling back upgrade", "reason", upgrade.Message)
+
+	rolledBack(upgrade, config.Generation)
+	if err := r.reconcileComponents(logger, r.components(), previous); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := updateStatus(r.Client, config, linkerdv1alpha1.ReconcileFailed, upgrade.Message, logger); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	return reconcile.Result{}, nil
+}
+
+// currentUpgrade returns the upgrade from the version in status to the given one. A new upgrade
+// starts when none is in progress for these versions, or when the spec of the given generation is not
+// the one that was rolled back
+func currentUpgrade(status *linkerdv1alpha1.LinkerdStatus, version linkerdv1alpha1.LinkerdVersion, generation int64) *linkerdv1alpha1.UpgradeStatus {
+	upgrade := status.Upgrade
+	if upgrade == nil || upgrade.FromVersion != status.Version || upgrade.ToVersion != version ||
+		(upgrade.Phase == linkerdv1alpha1.UpgradeRolledBack && upgrade.RolledBackGeneration != generation) {
+		upgrade = &linkerdv1alpha1.UpgradeStatus{
+			FromVersion: status.Version,
+			ToVersion:   version,
+			Phase:       linkerdv1alpha1.UpgradeProgressing,
+		}
+		status.Upgrade = upgrade
+	}
+	return upgrade
+}
+
+// startComponent makes the component the one rolling out the new version. It returns false when
+// the component already started rolling out
+func startComponent(upgrade *linkerdv1alpha1.UpgradeStatus, name string, now time.Time) bool {
+	if upgrade.CurrentComponent == name {
+		return false
+	}
+	start := metav1.NewTime(now)
+	upgrade.CurrentComponent = name
+	upgrade.CurrentComponentStartTime = &start
+	return true
+}
+
+// nextUpgradeStep records the rollout state of the current component and returns what to do next
+func nextUpgradeStep(upgrade *linkerdv1alpha1.UpgradeStatus, state k8sutil.RolloutState, timeout time.Duration, now time.Time) upgradeStep {
+	// a status written without the start time, e.g. by hand, starts the timeout now
+	if upgrade.CurrentComponentStartTime == nil {
+		start := metav1.NewTime(now)
+		upgrade.CurrentComponentStartTime = &start
+	}
+	switch {
+	case state == k8sutil.RolloutComplete:
+		upgrade.UpgradedComponents = append(upgrade.UpgradedComponents, upgrade.CurrentComponent)
+		upgrade.CurrentComponent = ""
+		upgrade.CurrentComponentStartTime = nil
+		return upgradeNextComponent
+	case state == k8sutil.RolloutFailed || now.Sub(upgrade.CurrentComponentStartTime.Time) > timeout:
+		upgrade.Message = fmt.Sprintf("component %s did not become ready within %s, rolled back to %s", upgrade.CurrentComponent, timeout, upgrade.FromVersion)
+		return upgradeRollBack
+	default:
+		upgrade.Message = fmt.Sprintf("waiting for component %s to roll out", upgrade.CurrentComponent)
+		return upgradeWait
+	}
+}
+
+// rolledBack records that every component went back to the previous version instead of the spec of
+// the given generation
+func rolledBack(upgrade *linkerdv1alpha1.UpgradeStatus, generation int64) {
+	upgrade.Phase = linkerdv1alpha1.UpgradeRolledBack
+	upgrade.RolledBackGeneration = generation
+	upgrade.UpgradedComponents = nil
+	upgrade.CurrentComponent = ""
+	upgrade.CurrentComponentStartTime = nil
+}
+
+// previousConfig returns the config rendering the given version. It is the last applied spec when it
+// runs that version, otherwise the current spec with the version swapped. The certificates are the
+// ones of the current config, so both versions trust each other
+func previousConfig(raw *linkerdv1alpha1.Linkerd, lastApplied *linkerdv1alpha1.LinkerdSpec, config *linkerdv1alpha1.Linkerd, version linkerdv1alpha1.LinkerdVersion) (*linkerdv1alpha1.Linkerd, error) {
+	release, ok := catalog.Lookup(string(version))
+	if !ok {
+		return nil, emperror.With(errors.New("previous Linkerd version is not in the release catalog"), "version", version)
+	}
+	previous := raw.DeepCopy()
+	if lastApplied != nil && lastApplied.Version == version {
+		lastApplied.DeepCopyInto(&previous.Spec)
+	}
+	previous.Spec.Version = version
+	previous.Spec.SelfSignedCertificates = config.Spec.SelfSignedCertificates
+	linkerdv1alpha1.SetDefaults(previous, release)
+	return previous, nil
+}
+
+// lastAppliedName is the name of the ConfigMap holding the spec the control plane last became available with
+func lastAppliedName(config *linkerdv1alpha1.Linkerd) string {
+	return config.Name + "-last-applied"
+}
+
+// lastAppliedSpec returns the spec the control plane last became available with, nil when it is unknown
+func (r *ReconcileLinkerd) lastAppliedSpec(config *linkerdv1alpha1.Linkerd) (*linkerdv1alpha1.LinkerdSpec, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: config.Namespace, Name: lastAppliedName(config)}, cm)
+	if k8errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not get the last applied spec", "name", lastAppliedName(config))
+	}
+	spec := &linkerdv1alpha1.LinkerdSpec{}
+	if err := json.Unmarshal([]byte(cm.Data[lastAppliedKey]), spec); err != nil {
+		return nil, emperror.WrapWith(err, "could not decode the last applied spec", "name", lastAppliedName(config))
+	}
+	return spec, nil
+}
+
+// saveLastAppliedSpec records the spec of raw as the one the control plane runs. The certificates are
+// left out, the previous version is rendered with the current ones
+func (r *ReconcileLinkerd) saveLastAppliedSpec(logger logr.Logger, raw *linkerdv1alpha1.Linkerd) error {
+	spec := raw.Spec.DeepCopy()
+	spec.SelfSignedCertificates = nil
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return emperror.Wrap(err, "could not encode the last applied spec")
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: templates.ObjectMeta(lastAppliedName(raw), nil, raw),
+		Data: map[string]string{
+			lastAppliedKey: string(data),
+		},
+	}
+	return emperror.Wrap(k8sutil.Reconcile(logger, r.Client, cm, k8sutil.DesiredStatePresent), "could not save the last applied spec")
+}
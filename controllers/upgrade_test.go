@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+var _ = Describe("Linkerd upgrades", func() {
+	const timeout = 5 * time.Minute
+	start := time.Date(2020, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	It("upgrades the components one after the other", func() {
+		status := &linkerdv1alpha1.LinkerdStatus{Version: "stable-2.8.0"}
+		upgrade := currentUpgrade(status, "stable-2.8.1", 1)
+		Expect(status.Upgrade).To(BeIdenticalTo(upgrade))
+		Expect(upgrade.FromVersion).To(Equal(linkerdv1alpha1.LinkerdVersion("stable-2.8.0")))
+		Expect(upgrade.Phase).To(Equal(linkerdv1alpha1.UpgradeProgressing))
+
+		By("starting the rollout of identity")
+		Expect(startComponent(upgrade, "identity", start)).To(BeTrue())
+		Expect(upgrade.CurrentComponent).To(Equal("identity"))
+		Expect(upgrade.CurrentComponentStartTime.Time).To(Equal(start))
+		Expect(nextUpgradeStep(upgrade, k8sutil.RolloutProgressing, timeout, start)).To(Equal(upgradeWait))
+		Expect(upgrade.Message).To(ContainSubstring("identity"))
+
+		By("checking the rollout again on the next pass")
+		Expect(startComponent(upgrade, "identity", start.Add(time.Minute))).To(BeFalse())
+		Expect(upgrade.CurrentComponentStartTime.Time).To(Equal(start))
+		Expect(nextUpgradeStep(upgrade, k8sutil.RolloutComplete, timeout, start.Add(time.Minute))).To(Equal(upgradeNextComponent))
+		Expect(upgrade.UpgradedComponents).To(Equal([]string{"identity"}))
+		Expect(upgrade.CurrentComponent).To(BeEmpty())
+		Expect(upgrade.CurrentComponentStartTime).To(BeNil())
+
+		By("moving on to destination")
+		Expect(startComponent(upgrade, "destination", start.Add(time.Minute))).To(BeTrue())
+		Expect(upgrade.CurrentComponent).To(Equal("destination"))
+
+		By("keeping the upgrade going on the next reconcile")
+		Expect(currentUpgrade(status, "stable-2.8.1", 1)).To(BeIdenticalTo(upgrade))
+	})
+
+	It("rolls back when a component fails or times out", func() {
+		for _, c := range []struct {
+			state k8sutil.RolloutState
+			now   time.Time
+			step  upgradeStep
+		}{
+			{k8sutil.RolloutProgressing, start.Add(timeout), upgradeWait},
+			{k8sutil.RolloutProgressing, start.Add(timeout + time.Second), upgradeRollBack},
+			{k8sutil.RolloutFailed, start.Add(time.Second), upgradeRollBack},
+		} {
+			upgrade := currentUpgrade(&linkerdv1alpha1.LinkerdStatus{Version: "stable-2.8.0"}, "stable-2.8.1", 1)
+			upgrade.UpgradedComponents = []string{"identity"}
+			startComponent(upgrade, "destination", start)
+			Expect(nextUpgradeStep(upgrade, c.state, timeout, c.now)).To(Equal(c.step))
+			if c.step != upgradeRollBack {
+				continue
+			}
+			Expect(upgrade.Message).To(ContainSubstring("destination did not become ready"))
+
+			rolledBack(upgrade, 1)
+			Expect(upgrade.Phase).To(Equal(linkerdv1alpha1.UpgradeRolledBack))
+			Expect(upgrade.UpgradedComponents).To(BeEmpty())
+			Expect(upgrade.CurrentComponent).To(BeEmpty())
+			Expect(upgrade.CurrentComponentStartTime).To(BeNil())
+		}
+	})
+
+	It("restarts the upgrade when the intended version changes", func() {
+		status := &linkerdv1alpha1.LinkerdStatus{Version: "stable-2.8.0"}
+		upgrade := currentUpgrade(status, "stable-2.8.1", 1)
+		rolledBack(upgrade, 1)
+
+		Expect(currentUpgrade(status, "stable-2.8.1", 1).Phase).To(Equal(linkerdv1alpha1.UpgradeRolledBack))
+		next := currentUpgrade(status, "stable-2.7.1", 1)
+		Expect(next).NotTo(BeIdenticalTo(upgrade))
+		Expect(next.Phase).To(Equal(linkerdv1alpha1.UpgradeProgressing))
+		Expect(next.ToVersion).To(Equal(linkerdv1alpha1.LinkerdVersion("stable-2.7.1")))
+	})
+
+	It("restarts the upgrade when the rolled back spec changes", func() {
+		status := &linkerdv1alpha1.LinkerdStatus{Version: "stable-2.8.0"}
+		upgrade := currentUpgrade(status, "stable-2.8.1", 1)
+		rolledBack(upgrade, 1)
+		Expect(upgrade.RolledBackGeneration).To(Equal(int64(1)))
+
+		next := currentUpgrade(status, "stable-2.8.1", 2)
+		Expect(next).NotTo(BeIdenticalTo(upgrade))
+		Expect(next.Phase).To(Equal(linkerdv1alpha1.UpgradeProgressing))
+		Expect(next.ToVersion).To(Equal(linkerdv1alpha1.LinkerdVersion("stable-2.8.1")))
+	})
+
+	It("starts the timeout when the start time of the current component is missing", func() {
+		upgrade := currentUpgrade(&linkerdv1alpha1.LinkerdStatus{Version: "stable-2.8.0"}, "stable-2.8.1", 1)
+		upgrade.CurrentComponent = "identity"
+
+		Expect(nextUpgradeStep(upgrade, k8sutil.RolloutProgressing, timeout, start)).To(Equal(upgradeWait))
+		Expect(upgrade.CurrentComponentStartTime.Time).To(Equal(start))
+		Expect(nextUpgradeStep(upgrade, k8sutil.RolloutProgressing, timeout, start.Add(timeout+time.Second))).To(Equal(upgradeRollBack))
+	})
+
+	It("rolls back to the last applied spec", func() {
+		ctx := context.Background()
+		r := &ReconcileLinkerd{Client: k8sClient, APIReader: k8sClient}
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-upgrades"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		config := &linkerdv1alpha1.Linkerd{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: ns.Name},
+			Spec: linkerdv1alpha1.LinkerdSpec{
+				Version:  "stable-2.8.0",
+				LogLevel: "debug",
+				Controller: linkerdv1alpha1.ControllerConfiguration{
+					BaseK8sResourceConfiguration: linkerdv1alpha1.BaseK8sResourceConfiguration{ReplicaCount: util.IntPointer(2)},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, config)).To(Succeed())
+		config.SetGroupVersionKind(linkerdv1alpha1.GroupVersion.WithKind("Linkerd"))
+
+		lastApplied, err := r.lastAppliedSpec(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lastApplied).To(BeNil())
+
+		By("saving the spec once applied")
+		config.Spec.SelfSignedCertificates = &linkerdv1alpha1.SelfSignedCertificates{KeyPEM: "key"}
+		Expect(r.saveLastAppliedSpec(logf.Log, config)).To(Succeed())
+		lastApplied, err = r.lastAppliedSpec(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lastApplied.LogLevel).To(Equal("debug"))
+		Expect(lastApplied.SelfSignedCertificates).To(BeNil())
+
+		By("changing the version along with other settings")
+		raw := config.DeepCopy()
+		raw.Spec.Version = "stable-2.8.1"
+		raw.Spec.LogLevel = "info"
+		raw.Spec.Controller.ReplicaCount = util.IntPointer(3)
+		current := raw.DeepCopy()
+		current.Spec.SelfSignedCertificates = &linkerdv1alpha1.SelfSignedCertificates{CrtPEM: "current"}
+
+		previous, err := previousConfig(raw, lastApplied, current, "stable-2.8.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(previous.Spec.Version).To(Equal(linkerdv1alpha1.LinkerdVersion("stable-2.8.0")))
+		Expect(previous.Spec.LogLevel).To(Equal("debug"))
+		Expect(*previous.Spec.Controller.ReplicaCount).To(Equal(int32(2)))
+		Expect(previous.Spec.SelfSignedCertificates).To(Equal(current.Spec.SelfSignedCertificates))
+
+		By("falling back to the current spec without a last applied spec of the version")
+		previous, err = previousConfig(raw, nil, current, "stable-2.8.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(previous.Spec.Version).To(Equal(linkerdv1alpha1.LinkerdVersion("stable-2.8.0")))
+		Expect(previous.Spec.LogLevel).To(Equal("info"))
+	})
+})
@@ -13,6 +13,7 @@ require (
 	github.com/prometheus/common v0.4.1
 	github.com/stretchr/testify v1.4.0
 	golang.org/x/time v0.0.0-20190308202827-9d24e82272b4
+	gopkg.in/yaml.v2 v2.2.8
 	k8s.io/api v0.18.2
 	k8s.io/apiextensions-apiserver v0.18.2
 	k8s.io/apimachinery v0.18.2
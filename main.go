@@ -19,18 +19,25 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 	"time"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/log"
 	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	linkerdv1alpha2 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha2"
 	"github.com/spaghettifunk/linkerd2-operator/controllers"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
 	// +kubebuilder:scaffold:imports
@@ -48,7 +55,10 @@ const (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+	utilruntime.Must(apiregistrationv1.AddToScheme(scheme))
 	utilruntime.Must(linkerdv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(linkerdv1alpha2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -57,54 +67,107 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var waitBeforeExitDuration time.Duration
+	var releasesConfigMap string
 	flag.BoolVar(&logDebug, "debug", false, "Enable log level debug mode.")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.DurationVar(&waitBeforeExitDuration, "wait-before-exit-duration", time.Duration(3)*time.Second, "Wait for workers to finish before exiting and removing finalizers")
+	flag.StringVar(&releasesConfigMap, "releases-configmap", "linkerd2-operator-releases", "Name of the ConfigMap in the operator namespace extending the catalog of Linkerd releases.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(logDebug)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	namespaces, err := getWatchNamespaces()
+	if err != nil {
+		setupLog.Error(err, "Failed to get watch namespace")
+		os.Exit(1)
+	}
+	options := ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
 		Port:               9443,
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "d76a60c4.linkerd.io",
 		MapperProvider:     k8sutil.NewCachedRESTMapper,
-	})
+		// only the resources of the operator are cached, it reads the other objects from the API server
+		NewClient: k8sutil.NewClient(&linkerdv1alpha1.Linkerd{}, &linkerdv1alpha1.Link{}),
+	}
+	switch len(namespaces) {
+	case 0:
+		setupLog.Info("watch all namespaces")
+	case 1:
+		setupLog.Info("watch namespace", "namespace", namespaces[0])
+		options.Namespace = namespaces[0]
+	default:
+		setupLog.Info("watch namespaces", "namespaces", namespaces)
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
-
-	log.Info("Watch namespaces.")
-
-	namespace, err := getWatchNamespace()
+	deployments, err := k8sutil.ManagedDeployments(mgr, namespaces)
 	if err != nil {
-		log.Error(err, "Failed to get watch namespace")
+		setupLog.Error(err, "unable to watch deployments")
 		os.Exit(1)
 	}
-	if namespace != "" {
-		log.Info("watch namespace", "namespace", namespace)
-	} else {
-		log.Info("watch all namespaces")
-	}
 
 	log.Info("Registering Components.")
 
+	releases := types.NamespacedName{
+		Namespace: os.Getenv(podNamespaceEnvVar),
+		Name:      releasesConfigMap,
+	}
 	reconciler := &controllers.ReconcileLinkerd{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Linkerd"),
-		Scheme: mgr.GetScheme(),
+		Client:            mgr.GetClient(),
+		APIReader:         mgr.GetAPIReader(),
+		Log:               ctrl.Log.WithName("controllers").WithName("Linkerd"),
+		Scheme:            mgr.GetScheme(),
+		RESTMapper:        mgr.GetRESTMapper(),
+		ReleasesConfigMap: releases,
+		Deployments:       deployments,
 	}
 
 	if err = reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Linkerd")
 		os.Exit(1)
 	}
+	dataPlaneReconciler := &controllers.DataPlaneReconciler{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("DataPlane"),
+	}
+	if err = dataPlaneReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DataPlane")
+		os.Exit(1)
+	}
+	// the webhooks need the serving certificate of config/certmanager, they are disabled to run the operator locally
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&linkerdv1alpha1.Linkerd{}).SetupWebhookWithManager(mgr, releases); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Linkerd")
+			os.Exit(1)
+		}
+		if err = (&linkerdv1alpha2.Linkerd{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Linkerd", "version", "v1alpha2")
+			os.Exit(1)
+		}
+	}
+	linkReconciler := &controllers.LinkReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Link"),
+		RemoteClient: func(kubeconfig []byte) (client.Client, error) {
+			return k8sutil.ClientFromKubeconfig(kubeconfig, mgr.GetScheme())
+		},
+		Deployments: deployments,
+	}
+	if err = linkReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Link")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
@@ -121,17 +184,16 @@ func main() {
 	// }
 }
 
-func getWatchNamespace() (string, error) {
-	podNamespace, found := os.LookupEnv(podNamespaceEnvVar)
-	if !found {
-		return "", errors.Errorf("%s env variable must be specified and cannot be empty", podNamespaceEnvVar)
+// getWatchNamespaces returns the namespaces of the comma-separated WATCH_NAMESPACE, none to watch every namespace
+func getWatchNamespaces() ([]string, error) {
+	if _, found := os.LookupEnv(podNamespaceEnvVar); !found {
+		return nil, errors.Errorf("%s env variable must be specified and cannot be empty", podNamespaceEnvVar)
 	}
-	watchNamespace, found := os.LookupEnv(watchNamespaceEnvVar)
-	if found {
-		if watchNamespace != "" && watchNamespace != podNamespace {
-			return "", errors.New("watch namespace must be either empty or equal to pod namespace")
+	var namespaces []string
+	for _, namespace := range strings.Split(os.Getenv(watchNamespaceEnvVar), ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
 		}
-
 	}
-	return watchNamespace, nil
+	return namespaces, nil
 }
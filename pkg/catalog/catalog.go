@@ -0,0 +1,247 @@
+package catalog
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultRegistry = "gcr.io/linkerd-io"
+
+// firewallBinPathProxyInitVersion is the first proxy-init accepting the --firewall-bin-path and
+// --firewall-save-bin-path flags that select the nft backend of iptables
+var firewallBinPathProxyInitVersion = version.MustParseGeneric("v1.4.0")
+
+// Release describes the images and the flags of a Linkerd release
+type Release struct {
+	// Version of the release, also the tag of the control plane images
+	Version string `yaml:"version"`
+	// ProxyVersion is the tag of the proxy image
+	ProxyVersion string `yaml:"proxyVersion"`
+	// ProxyInitVersion is the tag of the proxy-init image
+	ProxyInitVersion string `yaml:"proxyInitVersion"`
+	Images           Images `yaml:"images,omitempty"`
+	Flags            Flags  `yaml:"flags,omitempty"`
+}
+
+// Images are the repositories of the images of a release
+type Images struct {
+	Controller string `yaml:"controller,omitempty"`
+	Web        string `yaml:"web,omitempty"`
+	Grafana    string `yaml:"grafana,omitempty"`
+	CNI        string `yaml:"cni,omitempty"`
+	Debug      string `yaml:"debug,omitempty"`
+	Proxy      string `yaml:"proxy,omitempty"`
+	ProxyInit  string `yaml:"proxyInit,omitempty"`
+}
+
+// Flags lists the command line flags that are not accepted by every release
+type Flags struct {
+	// JaegerAddr is the -jaeger-addr flag of the web component
+	JaegerAddr bool `yaml:"jaegerAddr,omitempty"`
+}
+
+var defaultImages = Images{
+	Controller: defaultRegistry + "/controller",
+	Web:        defaultRegistry + "/web",
+	Grafana:    defaultRegistry + "/grafana",
+	CNI:        defaultRegistry + "/cni-plugin",
+	Debug:      defaultRegistry + "/debug",
+	Proxy:      defaultRegistry + "/proxy",
+	ProxyInit:  defaultRegistry + "/proxy-init",
+}
+
+// releases are the Linkerd releases known by the operator
+var releases = []Release{
+	{
+		Version:          "stable-2.7.0",
+		ProxyVersion:     "stable-2.7.0",
+		ProxyInitVersion: "v1.3.1",
+	},
+	{
+		Version:          "stable-2.7.1",
+		ProxyVersion:     "stable-2.7.1",
+		ProxyInitVersion: "v1.3.1",
+	},
+	{
+		Version:          "stable-2.8.0",
+		ProxyVersion:     "stable-2.8.0",
+		ProxyInitVersion: "v1.3.3",
+		Flags:            Flags{JaegerAddr: true},
+	},
+	{
+		Version:          "stable-2.8.1",
+		ProxyVersion:     "stable-2.8.1",
+		ProxyInitVersion: "v1.3.3",
+		Flags:            Flags{JaegerAddr: true},
+	},
+}
+
+// Image returns the reference of the given repository tagged with the release version
+func (r Release) Image(repository string) string {
+	return repository + ":" + r.Version
+}
+
+// ProxyImage returns the reference of the proxy image
+func (r Release) ProxyImage() string {
+	return r.Images.Proxy + ":" + r.ProxyVersion
+}
+
+// ProxyInitImage returns the reference of the proxy-init image
+func (r Release) ProxyInitImage() string {
+	return r.Images.ProxyInit + ":" + r.ProxyInitVersion
+}
+
+// SupportsIptablesNft tells whether the proxy-init of the release can configure the rules with iptables-nft
+func (r Release) SupportsIptablesNft() bool {
+	v, err := version.ParseGeneric(r.ProxyInitVersion)
+	if err != nil {
+		return false
+	}
+	return v.AtLeast(firewallBinPathProxyInitVersion)
+}
+
+// withDefaults fills the images not set by the release with the official ones
+func (r Release) withDefaults() Release {
+	for _, image := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&r.Images.Controller, defaultImages.Controller},
+		{&r.Images.Web, defaultImages.Web},
+		{&r.Images.Grafana, defaultImages.Grafana},
+		{&r.Images.CNI, defaultImages.CNI},
+		{&r.Images.Debug, defaultImages.Debug},
+		{&r.Images.Proxy, defaultImages.Proxy},
+		{&r.Images.ProxyInit, defaultImages.ProxyInit},
+	} {
+		if *image.value == "" {
+			*image.value = image.fallback
+		}
+	}
+	return r
+}
+
+func (r Release) validate() error {
+	if r.Version == "" {
+		return errors.New("version is required")
+	}
+	if r.ProxyVersion == "" {
+		return errors.Errorf("proxyVersion of release %s is required", r.Version)
+	}
+	if r.ProxyInitVersion == "" {
+		return errors.Errorf("proxyInitVersion of release %s is required", r.Version)
+	}
+	return nil
+}
+
+// Catalog maps the Linkerd versions to their release
+type Catalog struct {
+	mu         sync.RWMutex
+	builtin    map[string]Release
+	extensions map[string]Release
+}
+
+// New returns a catalog holding the releases built into the operator
+func New() *Catalog {
+	c := &Catalog{
+		builtin:    make(map[string]Release, len(releases)),
+		extensions: make(map[string]Release),
+	}
+	for _, r := range releases {
+		c.builtin[r.Version] = r.withDefaults()
+	}
+	return c
+}
+
+// Lookup returns the release of the given version
+func (c *Catalog) Lookup(version string) (Release, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if r, ok := c.extensions[version]; ok {
+		return r, true
+	}
+	r, ok := c.builtin[version]
+	return r, ok
+}
+
+// Versions returns the sorted versions of the catalog
+func (c *Catalog) Versions() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	versions := make([]string, 0, len(c.builtin)+len(c.extensions))
+	for v := range c.builtin {
+		versions = append(versions, v)
+	}
+	for v := range c.extensions {
+		if _, ok := c.builtin[v]; !ok {
+			versions = append(versions, v)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Extend replaces the releases added to the catalog with the ones in data, where each
+// value is a release in YAML. Releases override the built-in ones of the same version.
+// The catalog is left untouched when a release is invalid
+func (c *Catalog) Extend(data map[string]string) error {
+	extensions := make(map[string]Release, len(data))
+	for key, value := range data {
+		var r Release
+		if err := yaml.UnmarshalStrict([]byte(value), &r); err != nil {
+			return errors.Wrapf(err, "invalid release %q", key)
+		}
+		if err := r.validate(); err != nil {
+			return errors.Wrapf(err, "invalid release %q", key)
+		}
+		extensions[r.Version] = r.withDefaults()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.extensions = extensions
+	return nil
+}
+
+// operatorCatalog is the catalog shared by the controllers and the templates
+var operatorCatalog = New()
+
+// Lookup returns the release of the given version from the operator catalog
+func Lookup(version string) (Release, bool) {
+	return operatorCatalog.Lookup(version)
+}
+
+// Versions returns the versions supported by the operator
+func Versions() []string {
+	return operatorCatalog.Versions()
+}
+
+// Extend replaces the releases added to the operator catalog
+func Extend(data map[string]string) error {
+	return operatorCatalog.Extend(data)
+}
+
+// Load replaces the releases added to the operator catalog with the ones of the ConfigMap.
+// A missing ConfigMap leaves the built-in releases only
+func Load(reader client.Reader, key types.NamespacedName) error {
+	cm := &corev1.ConfigMap{}
+	err := reader.Get(context.TODO(), key, cm)
+	if apierrors.IsNotFound(err) {
+		return Extend(nil)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "could not get the releases ConfigMap %s", key)
+	}
+	return Extend(cm.Data)
+}
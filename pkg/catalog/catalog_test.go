@@ -0,0 +1,60 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	c := New()
+
+	r, ok := c.Lookup("stable-2.8.1")
+	assert.True(t, ok)
+	assert.Equal(t, "gcr.io/linkerd-io/controller:stable-2.8.1", r.Image(r.Images.Controller))
+	assert.Equal(t, "gcr.io/linkerd-io/proxy-init:v1.3.3", r.ProxyInitImage())
+	assert.True(t, r.Flags.JaegerAddr)
+	assert.False(t, r.SupportsIptablesNft())
+
+	r, ok = c.Lookup("stable-2.7.1")
+	assert.True(t, ok)
+	assert.False(t, r.Flags.JaegerAddr)
+
+	_, ok = c.Lookup("stable-1.0.0")
+	assert.False(t, ok)
+}
+
+func TestExtend(t *testing.T) {
+	c := New()
+
+	err := c.Extend(map[string]string{
+		"edge-20.7.1.yaml": `
+version: edge-20.7.1
+proxyVersion: edge-20.7.1
+proxyInitVersion: v1.4.0
+images:
+  proxy: registry.example.com/linkerd/proxy
+flags:
+  jaegerAddr: true
+`,
+	})
+	assert.NoError(t, err)
+
+	r, ok := c.Lookup("edge-20.7.1")
+	assert.True(t, ok)
+	assert.Equal(t, "registry.example.com/linkerd/proxy:edge-20.7.1", r.ProxyImage())
+	assert.Equal(t, "gcr.io/linkerd-io/web:edge-20.7.1", r.Image(r.Images.Web))
+	assert.True(t, r.SupportsIptablesNft())
+	assert.Contains(t, c.Versions(), "edge-20.7.1")
+
+	// an invalid release keeps the previous extensions
+	err = c.Extend(map[string]string{"broken.yaml": "version: edge-20.7.2\n"})
+	assert.Error(t, err)
+	_, ok = c.Lookup("edge-20.7.1")
+	assert.True(t, ok)
+
+	// releases removed from the ConfigMap are removed from the catalog
+	assert.NoError(t, c.Extend(nil))
+	_, ok = c.Lookup("edge-20.7.1")
+	assert.False(t, ok)
+}
@@ -6,7 +6,9 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"time"
 )
@@ -275,3 +277,92 @@ func (v *Validity) Window(t time.Time) (time.Time, time.Time) {
 	end := t.Add(life).Add(skew)
 	return start, end
 }
+
+// GenerateServingCertificate generates a certificate valid for the given DNS name, signed by a new root CA.
+// The CA is returned as the trust anchor, the webhooks and the API services are configured with it
+func GenerateServingCertificate(dnsName string) (*IdentityWithTrustedAnchor, error) {
+	root, err := GenerateRootCAWithDefaults(dnsName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate root certificate for %s: %s", dnsName, err)
+	}
+	cred, err := root.GenerateEndEntityCred(dnsName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serving certificate for %s: %s", dnsName, err)
+	}
+
+	return &IdentityWithTrustedAnchor{
+		TrustAnchorsPEM: root.Cred.Crt.EncodeCertificatePEM(),
+		KeyPEM:          cred.EncodePrivateKeyPEM(),
+		CrtPEM:          cred.Crt.EncodeCertificatePEM(),
+	}, nil
+}
+
+// VerifyServingCertificate checks that the certificate is signed by the trust anchors, is valid for the
+// given DNS name and does not expire before the given time
+func VerifyServingCertificate(it *IdentityWithTrustedAnchor, dnsName string, validUntil time.Time) error {
+	roots, err := DecodePEMCertPool(it.TrustAnchorsPEM)
+	if err != nil {
+		return err
+	}
+	crt, err := DecodePEMCrt(it.CrtPEM)
+	if err != nil {
+		return err
+	}
+	if _, err := DecodePEMKey(it.KeyPEM); err != nil {
+		return err
+	}
+	if err := crt.Verify(roots, dnsName); err != nil {
+		return err
+	}
+	if crt.Certificate.NotAfter.Before(validUntil) {
+		return fmt.Errorf("certificate expires at %s", crt.Certificate.NotAfter)
+	}
+	return nil
+}
+
+// GenerateIssuer issues an intermediate CA signing the identity certificates of a cluster. The serial number
+// is random since the clusters sharing the trust anchors issue their intermediates from the same signer
+func GenerateIssuer(signer Cred, name string) (*Cred, error) {
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+	ca := NewCA(signer, Validity{})
+	ca.nextSerialNumber = serialNumber.Uint64()
+	issuer, err := ca.GenerateCA(name, Validity{}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate issuer certificate for %s: %s", name, err)
+	}
+	return &issuer.Cred, nil
+}
+
+// VerifyIssuerCertificate checks that the issuer certificate chains to the trust anchors, matches its key, is
+// a CA and does not expire before the given time
+func VerifyIssuerCertificate(trustAnchorsPEM, crtPEM, keyPEM string, validUntil time.Time) error {
+	roots, err := DecodePEMCertPool(trustAnchorsPEM)
+	if err != nil {
+		return err
+	}
+	crt, err := DecodePEMCrt(crtPEM)
+	if err != nil {
+		return err
+	}
+	key, err := DecodePEMKey(keyPEM)
+	if err != nil {
+		return err
+	}
+	if !certificateMatchesKey(crt.Certificate, key) {
+		return errors.New("issuer certificate does not match its key")
+	}
+	if !crt.Certificate.IsCA {
+		return errors.New("issuer certificate is not a CA")
+	}
+	// the issuer has no DNS name to check, only its chain
+	if err := crt.Verify(roots, ""); err != nil {
+		return err
+	}
+	if crt.Certificate.NotAfter.Before(validUntil) {
+		return fmt.Errorf("issuer certificate expires at %s", crt.Certificate.NotAfter)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package certs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -11,3 +12,22 @@ func TestGenerateCA(t *testing.T) {
 	assert.NotNil(t, it)
 	assert.NotNil(t, err)
 }
+
+func TestGenerateIssuer(t *testing.T) {
+	root, err := GenerateRootCAWithDefaults("root.linkerd.cluster.local")
+	assert.NoError(t, err)
+	issuer, err := GenerateIssuer(root.Cred, "identity.linkerd.cluster.local")
+	assert.NoError(t, err)
+
+	trustAnchors := root.Cred.Crt.EncodeCertificatePEM()
+	assert.NoError(t, VerifyIssuerCertificate(trustAnchors, issuer.Crt.EncodePEM(), issuer.EncodePrivateKeyPEM(), time.Now()))
+	assert.Error(t, VerifyIssuerCertificate(trustAnchors, issuer.Crt.EncodePEM(), issuer.EncodePrivateKeyPEM(), time.Now().Add(2*DefaultLifetime)))
+
+	other, err := GenerateRootCAWithDefaults("other.linkerd.cluster.local")
+	assert.NoError(t, err)
+	assert.Error(t, VerifyIssuerCertificate(other.Cred.Crt.EncodeCertificatePEM(), issuer.Crt.EncodePEM(), issuer.EncodePrivateKeyPEM(), time.Now()))
+	// the end entity certificates of the issuer are not issuers
+	cred, err := NewCA(*issuer, Validity{}).GenerateEndEntityCred("web.default.serviceaccount.identity.linkerd.cluster.local")
+	assert.NoError(t, err)
+	assert.Error(t, VerifyIssuerCertificate(trustAnchors, cred.Crt.EncodePEM(), cred.EncodePrivateKeyPEM(), time.Now()))
+}
@@ -0,0 +1,250 @@
+package dataplane
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+const (
+	restartedAtAnnotation  = "linkerd.linkerd.io/restarted-at"
+	restartedForAnnotation = "linkerd.linkerd.io/restarted-for"
+	proxyVersionAnnotation = "linkerd.io/proxy-version"
+	injectAnnotation       = "linkerd.io/inject"
+	controlPlaneNsLabel    = "linkerd.io/control-plane-ns"
+	revisionLabel          = "linkerd.io/revision"
+	// concurrencyCheckPeriod is how often the rollouts are checked when the concurrency limit is reached
+	concurrencyCheckPeriod = 30 * time.Second
+)
+
+// Rollout restarts the workloads running an outdated proxy, or no proxy in an injected namespace
+type Rollout struct {
+	// Client writes the restarts
+	Client client.Client
+	// Reader lists the pods and the workloads of every namespace without caching them
+	Reader client.Reader
+	Config *linkerdv1alpha1.Linkerd
+}
+
+// New .
+func New(client client.Client, reader client.Reader, config *linkerdv1alpha1.Linkerd) *Rollout {
+	return &Rollout{
+		Client: client,
+		Reader: reader,
+		Config: config,
+	}
+}
+
+// Run restarts the next batch of workloads when the maintenance window, the batch interval and the
+// concurrency limit allow it. It returns the status of the data plane and when to run again
+func (r *Rollout) Run(log logr.Logger, now time.Time) (*linkerdv1alpha1.DataPlaneStatus, time.Duration, error) {
+	spec := r.Config.Spec.DataPlane
+	proxyVersion := templates.Release(r.Config.Spec).ProxyVersion
+
+	status := &linkerdv1alpha1.DataPlaneStatus{}
+	if r.Config.Status.DataPlane != nil {
+		status.LastRestartTime = r.Config.Status.DataPlane.LastRestartTime
+	}
+
+	workloads, err := r.pendingWorkloads(proxyVersion)
+	if err != nil {
+		return nil, 0, err
+	}
+	status.PendingWorkloads = int32(len(workloads))
+
+	restartable := make([]*workload, 0, len(workloads))
+	for _, w := range workloads {
+		switch {
+		case w.state != k8sutil.RolloutComplete:
+			status.RestartingWorkloads = append(status.RestartingWorkloads, w.key())
+		case w.restartedFor() == proxyVersion:
+			// restarting did not help, e.g. injection is disabled on the workload itself
+		default:
+			restartable = append(restartable, w)
+		}
+	}
+
+	interval := spec.BatchInterval.Duration
+	if len(restartable) == 0 {
+		return status, interval, nil
+	}
+	if open, wait := inMaintenanceWindow(spec.MaintenanceWindow, now); !open {
+		status.Message = "waiting for the maintenance window"
+		return status, wait, nil
+	}
+	if status.LastRestartTime != nil {
+		if elapsed := now.Sub(status.LastRestartTime.Time); elapsed < interval {
+			status.Message = "waiting for the batch interval"
+			return status, interval - elapsed, nil
+		}
+	}
+	batch := int(util.PointerToInt32(spec.MaxConcurrent)) - len(status.RestartingWorkloads)
+	if size := int(util.PointerToInt32(spec.BatchSize)); size < batch {
+		batch = size
+	}
+	if batch <= 0 {
+		status.Message = "waiting for the restarting workloads to roll out"
+		return status, concurrencyCheckPeriod, nil
+	}
+	if batch > len(restartable) {
+		batch = len(restartable)
+	}
+
+	for _, w := range restartable[:batch] {
+		log.Info("restarting workload", "workload", w.key(), "proxyVersion", proxyVersion)
+		if err := r.restart(w, now, proxyVersion); err != nil {
+			return nil, 0, err
+		}
+		status.RestartingWorkloads = append(status.RestartingWorkloads, w.key())
+	}
+	status.LastRestartTime = &metav1.Time{Time: now}
+	return status, interval, nil
+}
+
+// pendingWorkloads returns the workloads owning pods with an outdated proxy, or without proxy in an injected namespace
+func (r *Rollout) pendingWorkloads(proxyVersion string) ([]*workload, error) {
+	injected, err := r.injectedNamespaces()
+	if err != nil {
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.Reader.List(context.TODO(), pods); err != nil {
+		return nil, emperror.Wrap(err, "could not list pods")
+	}
+
+	owners := make(map[string]*workload)
+	pending := make(map[string]*workload)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !r.isPending(pod, injected, proxyVersion) {
+			continue
+		}
+		w, err := r.owner(pod, owners)
+		if err != nil {
+			return nil, err
+		}
+		if w != nil {
+			pending[w.key()] = w
+		}
+	}
+
+	workloads := make([]*workload, 0, len(pending))
+	for _, w := range pending {
+		workloads = append(workloads, w)
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		return workloads[i].key() < workloads[j].key()
+	})
+	return workloads, nil
+}
+
+// injectedNamespaces returns the namespaces whose pods get a proxy from this control plane
+func (r *Rollout) injectedNamespaces() (map[string]bool, error) {
+	namespaces := &corev1.NamespaceList{}
+	if err := r.Reader.List(context.TODO(), namespaces); err != nil {
+		return nil, emperror.Wrap(err, "could not list namespaces")
+	}
+	injected := make(map[string]bool)
+	for _, ns := range namespaces.Items {
+		// the namespaces of other revisions are injected by their own control plane
+		if ns.Annotations[injectAnnotation] == "enabled" && ns.Labels[revisionLabel] == r.Config.Spec.Revision {
+			injected[ns.Name] = true
+		}
+	}
+	for _, ns := range r.Config.Spec.AutoInjectionNamespaces {
+		injected[ns] = true
+	}
+	return injected, nil
+}
+
+func (r *Rollout) isPending(pod *corev1.Pod, injected map[string]bool, proxyVersion string) bool {
+	// the control plane is restarted by the upgrades
+	if pod.Namespace == r.Config.Namespace || pod.DeletionTimestamp != nil ||
+		pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if ns, meshed := pod.Labels[controlPlaneNsLabel]; meshed {
+		return ns == r.Config.Namespace && pod.Annotations[proxyVersionAnnotation] != proxyVersion
+	}
+	return injected[pod.Namespace] && pod.Annotations[injectAnnotation] != "disabled" && !pod.Spec.HostNetwork
+}
+
+// owner returns the workload of the pod, or nil when the pod is not part of a workload that can be restarted
+func (r *Rollout) owner(pod *corev1.Pod, owners map[string]*workload) (*workload, error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return nil, nil
+	}
+	if ref.Kind == "ReplicaSet" {
+		rs := &appsv1.ReplicaSet{}
+		err := r.Reader.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: ref.Name}, rs)
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, emperror.WrapWith(err, "could not get replicaset", "namespace", pod.Namespace, "name", ref.Name)
+		}
+		if ref = metav1.GetControllerOf(rs); ref == nil {
+			return nil, nil
+		}
+	}
+
+	var object runtime.Object
+	switch ref.Kind {
+	case "Deployment":
+		object = &appsv1.Deployment{}
+	case "StatefulSet":
+		object = &appsv1.StatefulSet{}
+	case "DaemonSet":
+		object = &appsv1.DaemonSet{}
+	default:
+		return nil, nil
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", ref.Kind, pod.Namespace, ref.Name)
+	if w, ok := owners[key]; ok {
+		return w, nil
+	}
+	err := r.Reader.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: ref.Name}, object)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not get workload", "workload", key)
+	}
+	w := newWorkload(object)
+	owners[key] = w
+	return w, nil
+}
+
+// restart triggers a rolling restart of the workload the same way kubectl rollout restart does
+func (r *Rollout) restart(w *workload, now time.Time, proxyVersion string) error {
+	original := w.object.DeepCopyObject()
+	if w.template.Annotations == nil {
+		w.template.Annotations = make(map[string]string)
+	}
+	w.template.Annotations[restartedAtAnnotation] = now.UTC().Format(time.RFC3339)
+	w.template.Annotations[restartedForAnnotation] = proxyVersion
+
+	if err := r.Client.Patch(context.TODO(), w.object, client.MergeFrom(original)); err != nil {
+		return emperror.WrapWith(err, "could not restart workload", "workload", w.key())
+	}
+	return nil
+}
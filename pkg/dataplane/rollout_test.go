@@ -0,0 +1,119 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+func deploymentWithPod(namespace, name string, podLabels, podAnnotations map[string]string) []runtime.Object {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(name)},
+		Spec:       appsv1.DeploymentSpec{Replicas: util.IntPointer(1)},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            name + "-5d8f9",
+			UID:             types.UID(name + "-5d8f9"),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment"))},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            name + "-5d8f9-x2k4p",
+			Labels:          podLabels,
+			Annotations:     podAnnotations,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rs, appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	return []runtime.Object{deployment, rs, pod}
+}
+
+func TestRun(t *testing.T) {
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "emojivoto", Annotations: map[string]string{injectAnnotation: "enabled"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	}
+	meshed := map[string]string{controlPlaneNsLabel: "linkerd"}
+	objects = append(objects, deploymentWithPod("emojivoto", "emoji", meshed, map[string]string{proxyVersionAnnotation: "stable-2.7.1"})...)
+	objects = append(objects, deploymentWithPod("emojivoto", "voting", meshed, map[string]string{proxyVersionAnnotation: "stable-2.8.1"})...)
+	objects = append(objects, deploymentWithPod("emojivoto", "web", nil, nil)...)
+	objects = append(objects, deploymentWithPod("default", "nginx", nil, nil)...)
+	c := fake.NewFakeClient(objects...)
+
+	config := &linkerdv1alpha1.Linkerd{
+		ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: "linkerd"},
+		Spec: linkerdv1alpha1.LinkerdSpec{
+			Version: "stable-2.8.1",
+			DataPlane: linkerdv1alpha1.DataPlaneConfiguration{
+				AutoRestart:   true,
+				BatchSize:     util.IntPointer(1),
+				BatchInterval: &metav1.Duration{Duration: time.Minute},
+				MaxConcurrent: util.IntPointer(2),
+			},
+		},
+	}
+	now := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	status, next, err := New(c, c, config).Run(logf.Log, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), status.PendingWorkloads)
+	assert.Equal(t, []string{"Deployment/emojivoto/emoji"}, status.RestartingWorkloads)
+	assert.Equal(t, time.Minute, next)
+
+	emoji := &appsv1.Deployment{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "emojivoto", Name: "emoji"}, emoji))
+	assert.Equal(t, "stable-2.8.1", emoji.Spec.Template.Annotations[restartedForAnnotation])
+	assert.Equal(t, "2020-07-01T10:00:00Z", emoji.Spec.Template.Annotations[restartedAtAnnotation])
+
+	// the next batch waits for the batch interval
+	config.Status.DataPlane = status
+	status, next, err = New(c, c, config).Run(logf.Log, now.Add(20*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 40*time.Second, next)
+	assert.Equal(t, "waiting for the batch interval", status.Message)
+
+	status, _, err = New(c, c, config).Run(logf.Log, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Deployment/emojivoto/web"}, status.RestartingWorkloads)
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	window := &linkerdv1alpha1.MaintenanceWindow{
+		Start:    "23:00",
+		Duration: metav1.Duration{Duration: 2 * time.Hour},
+		Days:     []linkerdv1alpha1.Weekday{"Saturday"},
+	}
+	// Saturday 4th of July 2020
+	saturday := time.Date(2020, 7, 4, 0, 0, 0, 0, time.UTC)
+
+	open, _ := inMaintenanceWindow(window, saturday.Add(23*time.Hour+30*time.Minute))
+	assert.True(t, open)
+	open, _ = inMaintenanceWindow(window, saturday.Add(24*time.Hour+30*time.Minute))
+	assert.True(t, open, "the window opened on saturday is still open after midnight")
+	open, wait := inMaintenanceWindow(window, saturday.Add(10*time.Hour))
+	assert.False(t, open)
+	assert.Equal(t, 13*time.Hour, wait)
+	open, wait = inMaintenanceWindow(window, saturday.Add(25*time.Hour))
+	assert.False(t, open)
+	assert.Equal(t, 6*24*time.Hour+22*time.Hour, wait)
+
+	open, _ = inMaintenanceWindow(nil, saturday)
+	assert.True(t, open)
+}
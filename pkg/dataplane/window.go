@@ -0,0 +1,48 @@
+package dataplane
+
+import (
+	"time"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+// inMaintenanceWindow returns whether now is inside the window, and otherwise how long until it opens.
+// A nil window is always open
+func inMaintenanceWindow(window *linkerdv1alpha1.MaintenanceWindow, now time.Time) (bool, time.Duration) {
+	if window == nil {
+		return true, 0
+	}
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		// rejected by the CRD validation
+		return false, time.Hour
+	}
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+
+	// the window opened yesterday may still be open after midnight
+	for _, opening := range []time.Time{today.AddDate(0, 0, -1), today} {
+		if opensOn(window, opening) && !now.Before(opening) && now.Before(opening.Add(window.Duration.Duration)) {
+			return true, 0
+		}
+	}
+	for days := 0; days <= 7; days++ {
+		opening := today.AddDate(0, 0, days)
+		if opensOn(window, opening) && opening.After(now) {
+			return false, opening.Sub(now)
+		}
+	}
+	return false, 24 * time.Hour
+}
+
+func opensOn(window *linkerdv1alpha1.MaintenanceWindow, t time.Time) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+	for _, day := range window.Days {
+		if string(day) == t.Weekday().String() {
+			return true
+		}
+	}
+	return false
+}
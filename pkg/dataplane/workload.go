@@ -0,0 +1,43 @@
+package dataplane
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+)
+
+// workload is a Deployment, StatefulSet or DaemonSet whose pods can be restarted
+type workload struct {
+	kind     string
+	object   runtime.Object
+	meta     metav1.Object
+	template *corev1.PodTemplateSpec
+	state    k8sutil.RolloutState
+}
+
+func newWorkload(object runtime.Object) *workload {
+	switch o := object.(type) {
+	case *appsv1.Deployment:
+		return &workload{kind: "Deployment", object: o, meta: o, template: &o.Spec.Template, state: k8sutil.DeploymentRolloutState(o)}
+	case *appsv1.StatefulSet:
+		return &workload{kind: "StatefulSet", object: o, meta: o, template: &o.Spec.Template, state: k8sutil.StatefulSetRolloutState(o)}
+	case *appsv1.DaemonSet:
+		return &workload{kind: "DaemonSet", object: o, meta: o, template: &o.Spec.Template, state: k8sutil.DaemonSetRolloutState(o)}
+	}
+	return nil
+}
+
+// key identifies the workload in the status
+func (w *workload) key() string {
+	return fmt.Sprintf("%s/%s/%s", w.kind, w.meta.GetNamespace(), w.meta.GetName())
+}
+
+// restartedFor returns the proxy version the operator last restarted the workload for
+func (w *workload) restartedFor() string {
+	return w.template.Annotations[restartedForAnnotation]
+}
@@ -0,0 +1,76 @@
+package k8sutil
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goph/emperror"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// NewClient returns the client of the manager. Only the objects of the kinds of cached are read from the
+// cache, the other ones are read from the API server: every kind read from the cache starts an informer,
+// which needs the permission to list and watch it in every watched namespace
+func NewClient(cached ...runtime.Object) manager.NewClientFunc {
+	return func(cache cache.Cache, config *rest.Config, options runtimeClient.Options) (runtimeClient.Client, error) {
+		c, err := runtimeClient.New(config, options)
+		if err != nil {
+			return nil, err
+		}
+		kinds := make(map[schema.GroupVersionKind]bool, len(cached))
+		for _, obj := range cached {
+			gvk, err := apiutil.GVKForObject(obj, options.Scheme)
+			if err != nil {
+				return nil, emperror.Wrap(err, "could not find the kind of a cached object")
+			}
+			kinds[gvk] = true
+		}
+		return &runtimeClient.DelegatingClient{
+			Reader: &cachedReader{
+				cache:  cache,
+				client: c,
+				scheme: options.Scheme,
+				kinds:  kinds,
+			},
+			Writer:       c,
+			StatusClient: c,
+		}, nil
+	}
+}
+
+// cachedReader reads the objects of the cached kinds from the cache and the other ones from the API server
+type cachedReader struct {
+	cache  runtimeClient.Reader
+	client runtimeClient.Reader
+	scheme *runtime.Scheme
+	kinds  map[schema.GroupVersionKind]bool
+}
+
+func (r *cachedReader) Get(ctx context.Context, key runtimeClient.ObjectKey, obj runtime.Object) error {
+	return r.reader(obj, false).Get(ctx, key, obj)
+}
+
+func (r *cachedReader) List(ctx context.Context, list runtime.Object, opts ...runtimeClient.ListOption) error {
+	return r.reader(list, true).List(ctx, list, opts...)
+}
+
+func (r *cachedReader) reader(obj runtime.Object, isList bool) runtimeClient.Reader {
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		// reported by the API server client
+		return r.client
+	}
+	if isList {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	if r.kinds[gvk] {
+		return r.cache
+	}
+	return r.client
+}
@@ -0,0 +1,48 @@
+package k8sutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// countingReader counts the reads instead of reading
+type countingReader struct {
+	reads int
+}
+
+func (r *countingReader) Get(context.Context, runtimeClient.ObjectKey, runtime.Object) error {
+	r.reads++
+	return nil
+}
+
+func (r *countingReader) List(context.Context, runtime.Object, ...runtimeClient.ListOption) error {
+	r.reads++
+	return nil
+}
+
+func TestCachedReader(t *testing.T) {
+	cache, client := &countingReader{}, &countingReader{}
+	reader := &cachedReader{
+		cache:  cache,
+		client: client,
+		scheme: clientgoscheme.Scheme,
+		kinds:  map[schema.GroupVersionKind]bool{appsv1.SchemeGroupVersion.WithKind("Deployment"): true},
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, reader.Get(ctx, runtimeClient.ObjectKey{}, &appsv1.Deployment{}))
+	assert.NoError(t, reader.List(ctx, &appsv1.DeploymentList{}))
+	assert.Equal(t, 2, cache.reads, "deployments are cached")
+
+	assert.NoError(t, reader.Get(ctx, runtimeClient.ObjectKey{}, &corev1.Secret{}))
+	assert.NoError(t, reader.List(ctx, &corev1.SecretList{}))
+	assert.Equal(t, 2, client.reads, "secrets are read from the API server")
+}
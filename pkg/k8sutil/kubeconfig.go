@@ -0,0 +1,22 @@
+package k8sutil
+
+import (
+	"github.com/goph/emperror"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClientFromKubeconfig returns a client of the cluster the kubeconfig points to, as used for the remote
+// clusters of the multicluster Links
+func ClientFromKubeconfig(kubeconfig []byte, scheme *runtime.Scheme) (runtimeClient.Client, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, emperror.Wrap(err, "invalid kubeconfig")
+	}
+	c, err := runtimeClient.New(config, runtimeClient.Options{Scheme: scheme})
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not create the client of the remote cluster")
+	}
+	return c, nil
+}
@@ -0,0 +1,67 @@
+package k8sutil
+
+import (
+	"github.com/goph/emperror"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ManagedByLabel marks the objects reconciled by the operator, its informers only watch them
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is the value of ManagedByLabel
+	ManagedByValue = "linkerd2-operator"
+)
+
+// setManagedBy labels the object as reconciled by the operator. The labels are copied since the components
+// share them with the selectors and the pod templates
+func setManagedBy(obj runtime.Object) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	labels := make(map[string]string, len(accessor.GetLabels())+1)
+	for k, v := range accessor.GetLabels() {
+		labels[k] = v
+	}
+	labels[ManagedByLabel] = ManagedByValue
+	accessor.SetLabels(labels)
+}
+
+// ManagedDeployments returns the sources of the Deployments reconciled by the operator, one per namespace or
+// a single one for every namespace when none is given. Their informers only list the Deployments labelled
+// with ManagedByLabel and are started by the manager
+func ManagedDeployments(mgr manager.Manager, namespaces []string) ([]source.Source, error) {
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not create the clientset of the informers")
+	}
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	sources := make([]source.Source, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+			informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.LabelSelector = ManagedByLabel + "=" + ManagedByValue
+			}),
+		)
+		informer := factory.Apps().V1().Deployments().Informer()
+		if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+			factory.Start(stop)
+			<-stop
+			return nil
+		})); err != nil {
+			return nil, emperror.Wrap(err, "could not add the informers to the manager")
+		}
+		sources = append(sources, &source.Informer{Informer: informer})
+	}
+	return sources, nil
+}
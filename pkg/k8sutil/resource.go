@@ -3,6 +3,7 @@ package k8sutil
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/go-logr/logr"
@@ -10,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -22,6 +24,7 @@ func Reconcile(log logr.Logger, client runtimeClient.Client, desired runtime.Obj
 		desiredState = DesiredStatePresent
 	}
 
+	setManagedBy(desired)
 	desiredType := reflect.TypeOf(desired)
 	var current = desired.DeepCopyObject()
 	var desiredCopy = desired.DeepCopyObject()
@@ -48,6 +51,9 @@ func Reconcile(log logr.Logger, client runtimeClient.Client, desired runtime.Obj
 			log.Info("resource created")
 		}
 	} else {
+		if err := checkOwnership(current, desired); err != nil {
+			return emperror.With(err, "kind", desiredType, "name", key.Name)
+		}
 		if desiredState == DesiredStatePresent {
 			patchResult, err := patch.DefaultPatchMaker.Calculate(current, desired, patch.IgnoreStatusFields())
 			if err != nil {
@@ -105,11 +111,47 @@ func Reconcile(log logr.Logger, client runtimeClient.Client, desired runtime.Obj
 	return nil
 }
 
+// checkOwnership returns an error when the current object is controlled by another owner than the desired
+// object, e.g. by another Linkerd resource installing a control plane of the same revision. Objects without
+// a controller, e.g. installed by the linkerd CLI, are adopted
+func checkOwnership(current, desired runtime.Object) error {
+	desiredMeta, err := meta.Accessor(desired)
+	if err != nil {
+		return err
+	}
+	desiredOwner := metav1.GetControllerOf(desiredMeta)
+	if desiredOwner == nil || desiredOwner.UID == "" {
+		return nil
+	}
+	currentMeta, err := meta.Accessor(current)
+	if err != nil {
+		return err
+	}
+	currentOwner := metav1.GetControllerOf(currentMeta)
+	if currentOwner == nil || currentOwner.UID == desiredOwner.UID {
+		return nil
+	}
+	return emperror.With(fmt.Errorf("ownership conflict: the resource is controlled by %s %q, not by %s %q",
+		currentOwner.Kind, currentOwner.Name, desiredOwner.Kind, desiredOwner.Name), "controller-uid", currentOwner.UID)
+}
+
 func prepareResourceForUpdate(current, desired runtime.Object) {
 	switch desired.(type) {
 	case *corev1.Service:
 		svc := desired.(*corev1.Service)
 		svc.Spec.ClusterIP = current.(*corev1.Service).Spec.ClusterIP
+	case *corev1.PersistentVolumeClaim:
+		// the spec of a claim is immutable except for its size, which can only grow:
+		// keep the current one so that a failed update never re-creates the claim and loses its data
+		pvc := desired.(*corev1.PersistentVolumeClaim)
+		currentSpec := current.(*corev1.PersistentVolumeClaim).Spec.DeepCopy()
+		if pvc.Spec.Resources.Requests.Storage().Cmp(*currentSpec.Resources.Requests.Storage()) > 0 {
+			if currentSpec.Resources.Requests == nil {
+				currentSpec.Resources.Requests = corev1.ResourceList{}
+			}
+			currentSpec.Resources.Requests[corev1.ResourceStorage] = *pvc.Spec.Resources.Requests.Storage()
+		}
+		pvc.Spec = *currentSpec
 	}
 }
 
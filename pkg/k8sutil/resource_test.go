@@ -0,0 +1,32 @@
+package k8sutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+func TestCheckOwnership(t *testing.T) {
+	configMap := func(owners ...types.UID) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "linkerd-config"}}
+		for _, uid := range owners {
+			cm.OwnerReferences = append(cm.OwnerReferences, metav1.OwnerReference{
+				Kind:       "Linkerd",
+				Name:       "linkerd",
+				UID:        uid,
+				Controller: util.BoolPointer(true),
+			})
+		}
+		return cm
+	}
+
+	assert.NoError(t, checkOwnership(configMap("a"), configMap("a")), "same owner")
+	assert.NoError(t, checkOwnership(configMap(), configMap("a")), "adopted")
+	assert.NoError(t, checkOwnership(configMap("a"), configMap()), "desired without owner")
+	assert.Error(t, checkOwnership(configMap("b"), configMap("a")), "controlled by another Linkerd")
+}
@@ -0,0 +1,99 @@
+package k8sutil
+
+import (
+	"context"
+
+	"github.com/goph/emperror"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RolloutState describes the progress of the rollout of a Deployment
+type RolloutState string
+
+const (
+	// RolloutComplete when every replica runs the latest template and is available
+	RolloutComplete RolloutState = "Complete"
+	// RolloutProgressing when the Deployment is still replacing its pods
+	RolloutProgressing RolloutState = "Progressing"
+	// RolloutFailed when the Deployment exceeded its progress deadline
+	RolloutFailed RolloutState = "Failed"
+)
+
+// DeploymentRolloutState returns the rollout state of a Deployment, following the logic of kubectl rollout status
+func DeploymentRolloutState(d *appsv1.Deployment) RolloutState {
+	if d.Generation > d.Status.ObservedGeneration {
+		return RolloutProgressing
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse && c.Reason == "ProgressDeadlineExceeded" {
+			return RolloutFailed
+		}
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	if d.Status.UpdatedReplicas < replicas ||
+		d.Status.Replicas > d.Status.UpdatedReplicas ||
+		d.Status.AvailableReplicas < d.Status.UpdatedReplicas {
+		return RolloutProgressing
+	}
+	return RolloutComplete
+}
+
+// StatefulSetRolloutState returns the rollout state of a StatefulSet, following the logic of kubectl rollout status
+func StatefulSetRolloutState(s *appsv1.StatefulSet) RolloutState {
+	if s.Generation > s.Status.ObservedGeneration {
+		return RolloutProgressing
+	}
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+	if s.Status.ReadyReplicas < replicas {
+		return RolloutProgressing
+	}
+	if s.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType && s.Status.UpdateRevision != s.Status.CurrentRevision {
+		return RolloutProgressing
+	}
+	return RolloutComplete
+}
+
+// DaemonSetRolloutState returns the rollout state of a DaemonSet, following the logic of kubectl rollout status
+func DaemonSetRolloutState(d *appsv1.DaemonSet) RolloutState {
+	if d.Generation > d.Status.ObservedGeneration {
+		return RolloutProgressing
+	}
+	if d.Status.UpdatedNumberScheduled < d.Status.DesiredNumberScheduled ||
+		d.Status.NumberAvailable < d.Status.DesiredNumberScheduled {
+		return RolloutProgressing
+	}
+	return RolloutComplete
+}
+
+// DeploymentsRolloutState returns the least advanced rollout state of the given Deployments.
+// Deployments that do not exist are considered complete
+func DeploymentsRolloutState(client runtimeClient.Client, namespace string, names ...string) (RolloutState, error) {
+	state := RolloutComplete
+	for _, name := range names {
+		d := &appsv1.Deployment{}
+		err := client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, d)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", emperror.WrapWith(err, "could not get deployment", "name", name)
+		}
+		switch DeploymentRolloutState(d) {
+		case RolloutFailed:
+			return RolloutFailed, nil
+		case RolloutProgressing:
+			state = RolloutProgressing
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,42 @@
+package k8sutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+func TestDeploymentRolloutState(t *testing.T) {
+	deployment := func(generation, observed int64, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		status.ObservedGeneration = observed
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: util.IntPointer(2)},
+			Status:     status,
+		}
+	}
+
+	assert.Equal(t, RolloutProgressing, DeploymentRolloutState(deployment(2, 1, appsv1.DeploymentStatus{
+		Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2,
+	})), "generation not observed yet")
+	assert.Equal(t, RolloutProgressing, DeploymentRolloutState(deployment(2, 2, appsv1.DeploymentStatus{
+		Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2,
+	})), "old replicas still running")
+	assert.Equal(t, RolloutProgressing, DeploymentRolloutState(deployment(2, 2, appsv1.DeploymentStatus{
+		Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1,
+	})), "new replicas not available")
+	assert.Equal(t, RolloutComplete, DeploymentRolloutState(deployment(2, 2, appsv1.DeploymentStatus{
+		Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2,
+	})))
+	assert.Equal(t, RolloutFailed, DeploymentRolloutState(deployment(2, 2, appsv1.DeploymentStatus{
+		Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2,
+		Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+		},
+	})))
+}
@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/goph/emperror"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/certs"
+)
+
+// servingCertificateRenewBefore is how long before its expiry a serving certificate is replaced
+const servingCertificateRenewBefore = 30 * 24 * time.Hour
+
+// Keys of the serving certificate in its Secret
+const (
+	ServingCertificateCAKey  = "ca.pem"
+	ServingCertificateCrtKey = "crt.pem"
+	ServingCertificateKeyKey = "key.pem"
+)
+
+// ServingCertificate returns the serving certificate stored in the Secret of the control plane namespace,
+// or a new one when the Secret does not exist yet, is not valid for the DNS name or expires soon.
+// Reusing it keeps the CA bundles of the webhooks stable across reconciliations
+func (r Reconciler) ServingCertificate(secretName, dnsName string) (*certs.IdentityWithTrustedAnchor, error) {
+	secret := &apiv1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: r.Config.Namespace, Name: secretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, emperror.WrapWith(err, "could not get serving certificate", "secret", secretName)
+	}
+	if err == nil {
+		current := &certs.IdentityWithTrustedAnchor{
+			TrustAnchorsPEM: string(secret.Data[ServingCertificateCAKey]),
+			CrtPEM:          string(secret.Data[ServingCertificateCrtKey]),
+			KeyPEM:          string(secret.Data[ServingCertificateKeyKey]),
+		}
+		if certs.VerifyServingCertificate(current, dnsName, time.Now().Add(servingCertificateRenewBefore)) == nil {
+			return current, nil
+		}
+	}
+
+	it, err := certs.GenerateServingCertificate(dnsName)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not generate serving certificate", "secret", secretName)
+	}
+	return it, nil
+}
@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+func TestServingCertificate(t *testing.T) {
+	const host = "linkerd-sp-validator.linkerd.svc"
+	config := &linkerdv1alpha1.Linkerd{ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: "linkerd"}}
+	r := Reconciler{Client: fake.NewFakeClient(), Config: config}
+
+	generated, err := r.ServingCertificate("linkerd-sp-validator-tls", host)
+	require.NoError(t, err)
+
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "linkerd-sp-validator-tls", Namespace: "linkerd"},
+		Data: map[string][]byte{
+			ServingCertificateCAKey:  []byte(generated.TrustAnchorsPEM),
+			ServingCertificateCrtKey: []byte(generated.CrtPEM),
+			ServingCertificateKeyKey: []byte(generated.KeyPEM),
+		},
+	}
+	require.NoError(t, r.Client.Create(context.TODO(), secret))
+
+	reused, err := r.ServingCertificate("linkerd-sp-validator-tls", host)
+	require.NoError(t, err)
+	assert.Equal(t, generated, reused, "the stored certificate is reused")
+
+	renewed, err := r.ServingCertificate("linkerd-sp-validator-tls", "linkerd-sp-validator.other.svc")
+	require.NoError(t, err)
+	assert.NotEqual(t, generated.CrtPEM, renewed.CrtPEM, "a certificate for another name is replaced")
+}
@@ -0,0 +1,199 @@
+package cni
+
+import (
+	"context"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/psp"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Namespace holds the CNI plugin shared by every control plane of the cluster
+	Namespace              = "linkerd-cni"
+	componentName          = "linkerd-cni"
+	serviceAccountName     = "linkerd-cni"
+	clusterRoleName        = "linkerd-cni"
+	clusterRoleBindingName = "linkerd-cni"
+	pspName                = "linkerd-cni"
+	roleName               = "linkerd-cni"
+	roleBindingName        = "linkerd-cni"
+	configmapName          = "linkerd-cni-config"
+	daemonSetName          = "linkerd-cni"
+)
+
+// Reconciler .
+type Reconciler struct {
+	resources.Reconciler
+	mapper meta.RESTMapper
+	// reader lists the Linkerd resources of every namespace, including the ones WATCH_NAMESPACE leaves out
+	reader client.Reader
+	// pspServed tells whether the pods of the plugin are admitted by PodSecurityPolicy or Pod Security Admission
+	pspServed bool
+}
+
+// New .
+func New(client client.Client, reader client.Reader, mapper meta.RESTMapper, config *linkerdv1alpha1.Linkerd) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client: client,
+			Config: config,
+		},
+		mapper: mapper,
+		reader: reader,
+	}
+}
+
+// Reconcile installs the CNI plugin once for the cluster: the DaemonSets of several control planes would
+// overwrite each other's plugin in the host directories. The oldest Linkerd resource enabling CNI installs it
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+
+	installer, err := r.installer()
+	if err != nil {
+		return err
+	}
+	if installer != nil && installer.UID != r.Config.UID {
+		log.Info("the CNI plugin is installed by another Linkerd resource", "installer", installer.Namespace+"/"+installer.Name)
+		return nil
+	}
+	desiredState := k8sutil.DesiredStatePresent
+	if installer == nil {
+		desiredState = k8sutil.DesiredStateAbsent
+	}
+
+	log.Info("Reconciling")
+
+	pspServed, err := psp.IsPodSecurityPolicyServed(r.mapper)
+	if err != nil {
+		return emperror.Wrap(err, "could not discover PodSecurityPolicy support")
+	}
+	r.pspServed = pspServed
+
+	objects := []resources.ResourceWithDesiredState{
+		{Resource: r.serviceAccount, DesiredState: desiredState},
+		{Resource: r.clusterRole, DesiredState: desiredState},
+		{Resource: r.clusterRoleBinding, DesiredState: desiredState},
+	}
+	if pspServed {
+		objects = append(objects, []resources.ResourceWithDesiredState{
+			{Resource: r.podSecurityPolicy, DesiredState: desiredState},
+			{Resource: r.role, DesiredState: desiredState},
+			{Resource: r.roleBinding, DesiredState: desiredState},
+		}...)
+	}
+	objects = append(objects, []resources.ResourceWithDesiredState{
+		{Resource: r.configmap, DesiredState: desiredState},
+		{Resource: r.daemonSet, DesiredState: desiredState},
+	}...)
+	// the namespace is created first and deleted last
+	namespace := resources.ResourceWithDesiredState{Resource: r.namespace, DesiredState: desiredState}
+	if desiredState == k8sutil.DesiredStatePresent {
+		objects = append([]resources.ResourceWithDesiredState{namespace}, objects...)
+	} else {
+		objects = append(objects, namespace)
+	}
+
+	for _, res := range objects {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// installer returns the oldest Linkerd resource enabling CNI in the cluster, nil when none does
+func (r *Reconciler) installer() (*linkerdv1alpha1.Linkerd, error) {
+	linkerds := &linkerdv1alpha1.LinkerdList{}
+	if err := r.reader.List(context.TODO(), linkerds); err != nil {
+		return nil, emperror.Wrap(err, "could not list Linkerd resources")
+	}
+	var installer *linkerdv1alpha1.Linkerd
+	for i := range linkerds.Items {
+		l := &linkerds.Items[i]
+		// the reconciled resource is the latest version of its own spec
+		if l.UID == r.Config.UID {
+			l = r.Config
+		}
+		if !l.Spec.CNI.Enabled || !l.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if installer == nil || precedes(l, installer) {
+			installer = l
+		}
+	}
+	return installer, nil
+}
+
+// precedes orders the Linkerd resources by creation
+func precedes(a, b *linkerdv1alpha1.Linkerd) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// objectMeta returns the metadata of a CNI object. The plugin is shared by the control planes, so its objects
+// have no owner and are removed once no Linkerd resource enables CNI rather than by the garbage collector
+func (r *Reconciler) objectMeta(name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   Namespace,
+		Labels:      labels,
+		Annotations: templates.DefaultAnnotations(string(r.Config.Spec.Version)),
+	}
+}
+
+// clusterObjectMeta returns the metadata of a cluster-scoped CNI object
+func (r *Reconciler) clusterObjectMeta(name string) metav1.ObjectMeta {
+	objectMeta := r.objectMeta(name, r.labels())
+	objectMeta.Namespace = ""
+	return objectMeta
+}
+
+func (r *Reconciler) namespace() runtime.Object {
+	objectMeta := r.clusterObjectMeta(Namespace)
+	// the pods of the plugin are not injected
+	objectMeta.Labels = util.MergeStringMaps(objectMeta.Labels, map[string]string{
+		"config.linkerd.io/admission-webhooks": "disabled",
+	})
+	if !r.pspServed {
+		objectMeta.Labels = util.MergeStringMaps(objectMeta.Labels, psp.PodSecurityLabels(psp.LevelPrivileged))
+	}
+	return &apiv1.Namespace{
+		ObjectMeta: objectMeta,
+	}
+}
+
+func (r *Reconciler) labels() map[string]string {
+	return map[string]string{
+		"linkerd.io/cni-resource": "true",
+	}
+}
+
+// podLabels returns the labels used for the pods of the cni daemonset
+func (r *Reconciler) podLabels() map[string]string {
+	return map[string]string{
+		"k8s-app":                 componentName,
+		"linkerd.io/cni-resource": "true",
+	}
+}
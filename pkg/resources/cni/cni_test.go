@@ -0,0 +1,94 @@
+package cni
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
+)
+
+func linkerd(namespace, revision string, created time.Time, cni bool) *linkerdv1alpha1.Linkerd {
+	return &linkerdv1alpha1.Linkerd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "linkerd",
+			Namespace:         namespace,
+			UID:               types.UID(namespace),
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: linkerdv1alpha1.LinkerdSpec{
+			Version:  "stable-2.8.1",
+			Revision: revision,
+			CNI:      linkerdv1alpha1.CNIConfiguration{Enabled: cni},
+		},
+	}
+}
+
+func TestInstaller(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, linkerdv1alpha1.AddToScheme(scheme))
+
+	created := time.Date(2020, time.July, 1, 12, 0, 0, 0, time.UTC)
+	stable := linkerd("linkerd", "", created, false)
+	edge := linkerd("linkerd-edge", "edge", created.Add(time.Hour), true)
+	canary := linkerd("linkerd-canary", "canary", created.Add(2*time.Hour), true)
+	c := fake.NewFakeClientWithScheme(scheme, stable, edge, canary)
+
+	installer, err := New(c, c, nil, canary).installer()
+	require.NoError(t, err)
+	assert.Equal(t, edge.UID, installer.UID, "the oldest Linkerd resource enabling CNI installs it")
+
+	// the spec being reconciled wins over the cached one
+	stable.Spec.CNI.Enabled = true
+	installer, err = New(c, c, nil, stable).installer()
+	require.NoError(t, err)
+	assert.Equal(t, stable.UID, installer.UID)
+
+	edge.Spec.CNI.Enabled = false
+	canary.Spec.CNI.Enabled = false
+	c = fake.NewFakeClientWithScheme(scheme, edge, canary)
+	installer, err = New(c, c, nil, edge).installer()
+	require.NoError(t, err)
+	assert.Nil(t, installer, "the plugin is removed once no Linkerd resource enables CNI")
+}
+
+func TestSharedObjects(t *testing.T) {
+	config := linkerd("linkerd-edge", "edge", time.Now(), true)
+	release, ok := catalog.Lookup(string(config.Spec.Version))
+	require.True(t, ok)
+	linkerdv1alpha1.SetDefaults(config, release)
+	r := New(nil, nil, nil, config)
+
+	daemonSet := r.daemonSet().(*appsv1.DaemonSet)
+	assert.Equal(t, Namespace, daemonSet.Namespace)
+	assert.Empty(t, daemonSet.OwnerReferences, "the plugin is shared by the control planes")
+
+	binding := r.clusterRoleBinding().(*rbacv1.ClusterRoleBinding)
+	assert.Equal(t, "linkerd-cni", binding.Name, "a single plugin is installed for every revision")
+	assert.Equal(t, "linkerd-cni", binding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: serviceAccountName, Namespace: Namespace}}, binding.Subjects)
+}
+
+func TestNamespacePodSecurity(t *testing.T) {
+	r := New(nil, nil, nil, linkerd("linkerd", "", time.Now(), true))
+
+	labels := r.namespace().(*corev1.Namespace).Labels
+	assert.Equal(t, "privileged", labels["pod-security.kubernetes.io/enforce"], "the daemonset uses the host network and paths")
+
+	r.pspServed = true
+	labels = r.namespace().(*corev1.Namespace).Labels
+	assert.NotContains(t, labels, "pod-security.kubernetes.io/enforce")
+	assert.Equal(t, "disabled", labels["config.linkerd.io/admission-webhooks"])
+}
@@ -0,0 +1,62 @@
+package cni
+
+import (
+	"encoding/json"
+
+	"github.com/hoisie/mustache"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var cniNetworkCfg = `{
+  "name": "linkerd-cni",
+  "type": "linkerd-cni",
+  "log_level": "{{logLevel}}",
+  "policy": {
+      "type": "k8s",
+      "k8s_api_root": "https://__KUBERNETES_SERVICE_HOST__:__KUBERNETES_SERVICE_PORT__",
+      "k8s_auth_token": "__SERVICEACCOUNT_TOKEN__"
+  },
+  "kubernetes": {
+      "kubeconfig": "__KUBECONFIG_FILEPATH__"
+  },
+  "linkerd": {
+    "incoming-proxy-port": 4143,
+    "outgoing-proxy-port": 4140,
+    "proxy-uid": 2102,
+    "ports-to-redirect": [],
+    "inbound-ports-to-ignore": {{{inboundPortsToIgnore}}},
+    "outbound-ports-to-ignore": {{{outboundPortsToIgnore}}},
+    "simulate": false,
+    "use-wait-flag": false
+  }
+}`
+
+func (r *Reconciler) configmap() runtime.Object {
+	cniConfig := r.Config.Spec.CNI
+	proxyInitConfig := r.Config.Spec.ProxyInit
+	return &apiv1.ConfigMap{
+		ObjectMeta: r.objectMeta(configmapName, r.labels()),
+		Data: map[string]string{
+			"dest_cni_net_dir": cniConfig.DestCNINetDir,
+			"dest_cni_bin_dir": cniConfig.DestCNIBinDir,
+			"cni_network_config": mustache.Render(cniNetworkCfg, map[string]string{
+				"logLevel":              cniConfig.LogLevel,
+				"inboundPortsToIgnore":  jsonList(append([]string{"4190", "4191"}, proxyInitConfig.IgnoreInboundPorts...)),
+				"outboundPortsToIgnore": jsonList(proxyInitConfig.IgnoreOutboundPorts),
+			}),
+		},
+	}
+}
+
+// jsonList renders the list of strings as a JSON array
+func jsonList(items []string) string {
+	if items == nil {
+		items = []string{}
+	}
+	out, err := json.Marshal(items)
+	if err != nil {
+		return "[]"
+	}
+	return string(out)
+}
@@ -0,0 +1,126 @@
+package cni
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (r *Reconciler) daemonSet() runtime.Object {
+	cniConfig := r.Config.Spec.CNI
+	return &appsv1.DaemonSet{
+		ObjectMeta: r.objectMeta(daemonSetName, util.MergeStringMaps(r.labels(), r.podLabels())),
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: r.podLabels(),
+			},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: util.IntstrPointer(1),
+				},
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: r.podLabels(),
+					Annotations: util.MergeStringMaps(
+						templates.DefaultAnnotations(string(r.Config.Spec.Version)),
+						map[string]string{
+							"linkerd.io/inject": "disabled",
+						},
+					),
+				},
+				Spec: apiv1.PodSpec{
+					NodeSelector: map[string]string{
+						"beta.kubernetes.io/os": "linux",
+					},
+					HostNetwork:        true,
+					ServiceAccountName: serviceAccountName,
+					Tolerations:        cniConfig.Tolerations,
+					Affinity:           cniConfig.Affinity,
+					Containers:         r.containers(),
+					Volumes: []apiv1.Volume{
+						{
+							Name: "cni-bin-dir",
+							VolumeSource: apiv1.VolumeSource{
+								HostPath: &apiv1.HostPathVolumeSource{
+									Path: cniConfig.DestCNIBinDir,
+								},
+							},
+						},
+						{
+							Name: "cni-net-dir",
+							VolumeSource: apiv1.VolumeSource{
+								HostPath: &apiv1.HostPathVolumeSource{
+									Path: cniConfig.DestCNINetDir,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) containers() []apiv1.Container {
+	cniConfig := r.Config.Spec.CNI
+	containers := []apiv1.Container{
+		{
+			Name:            "install-cni",
+			Image:           *cniConfig.Image,
+			ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
+			Env: []apiv1.EnvVar{
+				r.configMapEnvVar("DEST_CNI_NET_DIR", "dest_cni_net_dir"),
+				r.configMapEnvVar("DEST_CNI_BIN_DIR", "dest_cni_bin_dir"),
+				r.configMapEnvVar("CNI_NETWORK_CONFIG", "cni_network_config"),
+				{
+					Name:  "SLEEP",
+					Value: "true",
+				},
+			},
+			Lifecycle: &apiv1.Lifecycle{
+				PreStop: &apiv1.Handler{
+					Exec: &apiv1.ExecAction{
+						Command: []string{"kill", "-15", "1"},
+					},
+				},
+			},
+			Resources: *cniConfig.Resources,
+			SecurityContext: &apiv1.SecurityContext{
+				ReadOnlyRootFilesystem: util.BoolPointer(true),
+			},
+			VolumeMounts: []apiv1.VolumeMount{
+				{
+					MountPath: "/host/opt/cni/bin",
+					Name:      "cni-bin-dir",
+				},
+				{
+					MountPath: "/host/etc/cni/net.d",
+					Name:      "cni-net-dir",
+				},
+			},
+			TerminationMessagePath:   apiv1.TerminationMessagePathDefault,
+			TerminationMessagePolicy: apiv1.TerminationMessageReadFile,
+		},
+	}
+	return containers
+}
+
+func (r *Reconciler) configMapEnvVar(name, key string) apiv1.EnvVar {
+	return apiv1.EnvVar{
+		Name: name,
+		ValueFrom: &apiv1.EnvVarSource{
+			ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
+				LocalObjectReference: apiv1.LocalObjectReference{
+					Name: configmapName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package cni
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func (r *Reconciler) serviceAccount() runtime.Object {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: r.objectMeta(serviceAccountName, r.labels()),
+	}
+}
+
+func (r *Reconciler) clusterRole() runtime.Object {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: r.clusterObjectMeta(clusterRoleName),
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods", "nodes", "namespaces"},
+				Verbs:     []string{"list", "get", "watch"},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) clusterRoleBinding() runtime.Object {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: r.clusterObjectMeta(clusterRoleBindingName),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     clusterRoleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      serviceAccountName,
+				Namespace: Namespace,
+			},
+		},
+	}
+}
+
+// podSecurityPolicy allows the cni daemonset to write the plugin on the host
+func (r *Reconciler) podSecurityPolicy() runtime.Object {
+	cniConfig := r.Config.Spec.CNI
+	return &policyv1.PodSecurityPolicy{
+		ObjectMeta: r.clusterObjectMeta(pspName),
+		Spec: policyv1.PodSecurityPolicySpec{
+			AllowPrivilegeEscalation: util.BoolPointer(false),
+			RequiredDropCapabilities: []apiv1.Capability{"ALL"},
+			HostNetwork:              true,
+			HostIPC:                  false,
+			HostPID:                  false,
+			SELinux: policyv1.SELinuxStrategyOptions{
+				Rule: policyv1.SELinuxStrategyRunAsAny,
+			},
+			RunAsUser: policyv1.RunAsUserStrategyOptions{
+				Rule: policyv1.RunAsUserStrategyRunAsAny,
+			},
+			SupplementalGroups: policyv1.SupplementalGroupsStrategyOptions{
+				Rule: policyv1.SupplementalGroupsStrategyRunAsAny,
+			},
+			FSGroup: policyv1.FSGroupStrategyOptions{
+				Rule: policyv1.FSGroupStrategyRunAsAny,
+			},
+			AllowedHostPaths: []policyv1.AllowedHostPath{
+				{PathPrefix: cniConfig.DestCNINetDir},
+				{PathPrefix: cniConfig.DestCNIBinDir},
+			},
+			Volumes: []policyv1.FSType{
+				policyv1.HostPath,
+				policyv1.Secret,
+			},
+		},
+	}
+}
+
+func (r *Reconciler) role() runtime.Object {
+	return &rbacv1.Role{
+		ObjectMeta: r.objectMeta(roleName, r.labels()),
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{"policy", "extensions"},
+				Resources:     []string{"podsecuritypolicies"},
+				Verbs:         []string{"use"},
+				ResourceNames: []string{pspName},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) roleBinding() runtime.Object {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: r.objectMeta(roleBindingName, r.labels()),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     roleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      serviceAccountName,
+				Namespace: Namespace,
+			},
+		},
+	}
+}
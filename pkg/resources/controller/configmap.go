@@ -1,15 +1,20 @@
 package controller
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
 	"github.com/hoisie/mustache"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var globalCfg = `{
-    "linkerdNamespace": "linkerd",
-    "cniEnabled": false,
+    "linkerdNamespace": "{{namespace}}",
+    "cniEnabled": {{cniEnabled}},
     "version": "{{version}}",
     "identityContext": {
         "trustDomain": "{{trustDomain}}",
@@ -26,18 +31,18 @@ var globalCfg = `{
 var proxyCfg = `
 {
     "proxyImage": {
-        "imageName": "gcr.io/linkerd-io/proxy",
+        "imageName": "{{proxyImageName}}",
         "pullPolicy": "IfNotPresent"
     },
     "proxyInitImage": {
-        "imageName": "gcr.io/linkerd-io/proxy-init",
+        "imageName": "{{proxyInitImageName}}",
         "pullPolicy": "IfNotPresent"
     },
     "controlPort": {
         "port": 4190
     },
-    "ignoreInboundPorts": [],
-    "ignoreOutboundPorts": [],
+    "ignoreInboundPorts": {{{ignoreInboundPorts}}},
+    "ignoreOutboundPorts": {{{ignoreOutboundPorts}}},
     "inboundPort": {
         "port": 4143
     },
@@ -58,10 +63,10 @@ var proxyCfg = `
         "level": "warn,linkerd=info"
     },
     "disableExternalProfiles": true,
-    "proxyVersion": "{{version}}",
+    "proxyVersion": "{{proxyVersion}}",
     "proxyInitImageVersion": "{{proxyInitImageVersion}}",
     "debugImage": {
-        "imageName": "gcr.io/linkerd-io/debug",
+        "imageName": "{{debugImageName}}",
         "pullPolicy": "IfNotPresent"
     },
     "debugImageVersion": "{{version}}",
@@ -80,23 +85,69 @@ var installCfg = `
 }
 `
 
+var tracingCfg = `
+{
+    "collectorSvcAddr": "{{collectorSvcAddr}}",
+    "collectorSvcName": "{{collectorSvcName}}",
+    "samplingPercentage": {{samplingPercentage}}
+}
+`
+
 func (r *Reconciler) configmap() runtime.Object {
-	return &apiv1.ConfigMap{
+	version := string(r.Config.Spec.Version)
+	release := templates.Release(r.Config.Spec)
+	cm := &apiv1.ConfigMap{
 		ObjectMeta: templates.ObjectMetaWithAnnotations(configmapName, r.labels(), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
 		Data: map[string]string{
 			"global": mustache.Render(globalCfg, map[string]string{
-				"version":       "stable-2.8.1",
+				"namespace":     r.Config.Namespace,
+				"version":       version,
+				"cniEnabled":    strconv.FormatBool(r.Config.Spec.CNI.Enabled),
 				"trustDomain":   "cluster.local",
 				"clusterDomain": "cluster.local",
 			}),
 			"proxy": mustache.Render(proxyCfg, map[string]string{
-				"version":               "stable-2.8.1",
-				"proxyInitImageVersion": "v1.3.3",
+				"version":               version,
+				"proxyVersion":          release.ProxyVersion,
+				"proxyImageName":        release.Images.Proxy,
+				"proxyInitImageName":    release.Images.ProxyInit,
+				"proxyInitImageVersion": release.ProxyInitVersion,
+				"debugImageName":        release.Images.Debug,
+				"ignoreInboundPorts":    portRanges(r.Config.Spec.ProxyInit.IgnoreInboundPorts),
+				"ignoreOutboundPorts":   portRanges(r.Config.Spec.ProxyInit.IgnoreOutboundPorts),
 			}),
 			"install": mustache.Render(installCfg, map[string]string{
-				"version": "stable-2.8.1",
-				"isHA":    "false",
+				"version": version,
+				"isHA":    strconv.FormatBool(r.Config.Spec.IsHighlyAvailable()),
 			}),
 		},
 	}
+
+	tracingConfig := r.Config.Spec.Tracing
+	if tracingConfig.Enabled {
+		collectorSvcName := tracingConfig.Collector.ExternalIdentity
+		if tracingConfig.Collector.ExternalAddr == "" {
+			collectorSvcName = fmt.Sprintf("linkerd-collector.%s.serviceaccount.identity.%s.%s", r.Config.Namespace, r.Config.Namespace, "cluster.local")
+		}
+		cm.Data["tracing"] = mustache.Render(tracingCfg, map[string]string{
+			"collectorSvcAddr":   templates.TraceCollectorAddr(r.Config),
+			"collectorSvcName":   collectorSvcName,
+			"samplingPercentage": strconv.Itoa(int(util.PointerToInt32(tracingConfig.Collector.SamplingPercentage))),
+		})
+	}
+
+	return cm
+}
+
+// portRanges renders the list of ports in the PortRange format expected by linkerd-config
+func portRanges(ports []string) string {
+	ranges := make([]map[string]string, 0, len(ports))
+	for _, port := range ports {
+		ranges = append(ranges, map[string]string{"portRange": port})
+	}
+	out, err := json.Marshal(ranges)
+	if err != nil {
+		return "[]"
+	}
+	return string(out)
 }
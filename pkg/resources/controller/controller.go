@@ -7,6 +7,7 @@ import (
 	"github.com/goph/emperror"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,6 +24,8 @@ const (
 // Reconciler .
 type Reconciler struct {
 	resources.Reconciler
+	// prometheusURL is the URL of the external prometheus with its basic auth credentials
+	prometheusURL string
 }
 
 // New .
@@ -43,7 +46,18 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
+	prometheusURLState := k8sutil.DesiredStateAbsent
+	if templates.PrometheusBasicAuth(r.Config) {
+		prometheusURL, err := r.prometheusURLWithCredentials()
+		if err != nil {
+			return err
+		}
+		r.prometheusURL = prometheusURL
+		prometheusURLState = desiredState
+	}
+
 	for _, res := range []resources.ResourceWithDesiredState{
+		{Resource: r.prometheusURLSecret, DesiredState: prometheusURLState},
 		{Resource: r.serviceAccount, DesiredState: desiredState},
 		{Resource: r.clusterRole, DesiredState: desiredState},
 		{Resource: r.clusterRoleBinding, DesiredState: desiredState},
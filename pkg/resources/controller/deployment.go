@@ -42,7 +42,7 @@ func (r *Reconciler) deployment() runtime.Object {
 				Spec: apiv1.PodSpec{
 					ServiceAccountName: serviceAccountName,
 					Containers:         r.containers(),
-					InitContainers:     templates.ProxyInitContainer(),
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
 					Volumes: []apiv1.Volume{
 						{
 							Name: "config",
@@ -62,7 +62,6 @@ func (r *Reconciler) deployment() runtime.Object {
 								},
 							},
 						},
-						// TODO: add Tracing labels here
 					},
 				},
 			},
@@ -74,20 +73,22 @@ func (r *Reconciler) containers() []apiv1.Container {
 
 	args := []string{
 		"public-api",
-		fmt.Sprintf("-prometheus-url=http://linkerd-prometheus.%s.svc.%s:9090", r.Config.Namespace, "cluster.local"),
+		"-prometheus-url=" + templates.PrometheusURL(r.Config),
 		fmt.Sprintf("-destination-addr=linkerd-dst.%s.svc.%s:8086", r.Config.Namespace, "cluster.local"),
 		"-controller-namespace=" + r.Config.Namespace,
 		"-log-level=info",
 	}
+	args = append(args, templates.TracingArgs(r.Config)...)
 
 	controllerConfig := r.Config.Spec.Controller
 	containers := []apiv1.Container{
-		templates.DefaultProxyContainer(r.Config.Spec),
+		templates.DefaultProxyContainer(r.Config),
 		{
 			Name:            "public-api",
 			Image:           *controllerConfig.Image,
 			ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
 			Args:            args,
+			Env:             templates.PrometheusURLEnv(r.Config),
 			LivenessProbe:   templates.DefaultLivenessProbe("/ping", 9995, 10, 30),
 			ReadinessProbe:  templates.DefaultReadinessProbe("/ready", 9995, 7, 30),
 			Resources:       *controllerConfig.Resources,
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+)
+
+// prometheusURLWithCredentials reads the basic auth credentials of the external prometheus and returns its URL
+// embedding them, since the public-api and the heartbeat only take the URL of prometheus
+func (r *Reconciler) prometheusURLWithCredentials() (string, error) {
+	name := r.Config.Spec.Prometheus.Credentials.SecretName
+	secret := &apiv1.Secret{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: r.Config.Namespace, Name: name}, secret); err != nil {
+		return "", emperror.WrapWith(err, "could not get the prometheus credentials", "secret", name)
+	}
+	username, password := secret.Data["username"], secret.Data["password"]
+	if len(username) == 0 || len(password) == 0 {
+		return "", emperror.With(errors.New("the prometheus credentials need a username and a password key"), "secret", name)
+	}
+	prometheusURL, err := templates.PrometheusURLWithCredentials(r.Config.Spec.Prometheus.URL, string(username), string(password))
+	if err != nil {
+		return "", emperror.Wrap(err, "invalid prometheus URL")
+	}
+	return prometheusURL, nil
+}
+
+// prometheusURLSecret holds the URL of the external prometheus with its credentials, read by the
+// components from their environment so that the credentials stay out of their pod spec
+func (r *Reconciler) prometheusURLSecret() runtime.Object {
+	return &apiv1.Secret{
+		ObjectMeta: templates.ObjectMeta(templates.PrometheusURLSecretName, r.labels(), r.Config),
+		Type:       apiv1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			templates.PrometheusURLKey: []byte(r.prometheusURL),
+		},
+	}
+}
@@ -40,7 +40,7 @@ func (r *Reconciler) deployment() runtime.Object {
 				Spec: apiv1.PodSpec{
 					ServiceAccountName: serviceAccountName,
 					Containers:         r.containers(),
-					InitContainers:     templates.ProxyInitContainer(),
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
 					Volumes: []apiv1.Volume{
 						{
 							Name: "config",
@@ -60,7 +60,6 @@ func (r *Reconciler) deployment() runtime.Object {
 								},
 							},
 						},
-						// TODO: add Tracing labels here
 					},
 				},
 			},
@@ -77,6 +76,7 @@ func (r *Reconciler) containers() []apiv1.Container {
 		"-enable-h2-upgrade=true",
 		"-log-level=info",
 	}
+	args = append(args, templates.TracingArgs(r.Config)...)
 
 	destinationConfig := r.Config.Spec.Destination
 	containers := []apiv1.Container{
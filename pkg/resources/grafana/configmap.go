@@ -0,0 +1,97 @@
+package grafana
+
+import (
+	"fmt"
+
+	"github.com/hoisie/mustache"
+	"github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var grafanaIni = `instance_name = linkerd-grafana
+
+[server]
+root_url = %(protocol)s://%(domain)s:/grafana/
+
+[auth]
+disable_login_form = true
+
+[auth.anonymous]
+enabled = true
+org_role = Editor
+
+[auth.basic]
+enabled = false
+
+[analytics]
+check_for_updates = false
+
+[panels]
+disable_sanitize_html = true
+`
+
+var datasourcesCfg = `apiVersion: 1
+datasources:
+- name: prometheus
+  type: prometheus
+  access: proxy
+  orgId: 1
+  url: {{prometheusURL}}
+  isDefault: true
+{{#basicAuth}}
+  basicAuth: true
+  basicAuthUser: $PROMETHEUS_USERNAME
+  secureJsonData:
+    basicAuthPassword: $PROMETHEUS_PASSWORD
+{{/basicAuth}}
+  jsonData:
+    timeInterval: "5s"
+  version: 1
+  editable: true
+`
+
+// dashboardsCfg provisions the Linkerd dashboards shipped within the grafana image
+var dashboardsCfg = `apiVersion: 1
+providers:
+- name: 'default'
+  orgId: 1
+  folder: ''
+  type: file
+  disableDeletion: true
+  editable: true
+  options:
+    path: /var/lib/grafana/dashboards
+    homeDashboardId: linkerd-top-line
+`
+
+func (r *Reconciler) configmap() runtime.Object {
+	// grafana reads the credentials from its environment, the URL must not embed them
+	prometheusURL := fmt.Sprintf("http://linkerd-prometheus.%s.svc.%s:9090", r.Config.Namespace, "cluster.local")
+	var credentials *v1alpha1.PrometheusCredentials
+	if !r.Config.Spec.Prometheus.IsDeployed() {
+		prometheusURL = r.Config.Spec.Prometheus.URL
+		credentials = r.Config.Spec.Prometheus.Credentials
+	}
+
+	return &apiv1.ConfigMap{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(configmapName, r.labels(), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
+		Data: map[string]string{
+			"grafana.ini": grafanaIni,
+			"datasources.yaml": mustache.Render(datasourcesCfg, map[string]interface{}{
+				"prometheusURL": prometheusURL,
+				"basicAuth":     credentials != nil && credentials.Type == v1alpha1.PrometheusAuthBasic,
+			}),
+		},
+	}
+}
+
+func (r *Reconciler) dashboardConfigmap() runtime.Object {
+	return &apiv1.ConfigMap{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(dashboardConfigmapName, r.labels(), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
+		Data: map[string]string{
+			"dashboards.yaml": dashboardsCfg,
+		},
+	}
+}
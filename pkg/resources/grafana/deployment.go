@@ -0,0 +1,137 @@
+package grafana
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (r *Reconciler) deployment() runtime.Object {
+	labels := util.MergeStringMaps(r.labels(), r.deploymentLabels())
+	grafanaConfig := r.Config.Spec.Grafana
+	return &appsv1.Deployment{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(
+			deploymentName,
+			util.MergeMultipleStringMaps(r.deploymentLabels(), r.labels()),
+			templates.DefaultAnnotations(string(r.Config.Spec.Version)),
+			r.Config,
+		),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: grafanaConfig.ReplicaCount,
+			Selector: &v1.LabelSelector{
+				MatchLabels: r.labels(),
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: util.MergeStringMaps(templates.DefaultAnnotations(string(r.Config.Spec.Version)), grafanaConfig.PodAnnotations),
+				},
+				Spec: apiv1.PodSpec{
+					ServiceAccountName: serviceAccountName,
+					Containers:         r.containers(),
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
+					NodeSelector:       grafanaConfig.NodeSelector,
+					Affinity:           grafanaConfig.Affinity,
+					Tolerations:        grafanaConfig.Tolerations,
+					Volumes: []apiv1.Volume{
+						{
+							Name: "data",
+							VolumeSource: apiv1.VolumeSource{
+								EmptyDir: &apiv1.EmptyDirVolumeSource{},
+							},
+						},
+						{
+							Name: "grafana-config",
+							VolumeSource: apiv1.VolumeSource{
+								ConfigMap: &apiv1.ConfigMapVolumeSource{
+									LocalObjectReference: apiv1.LocalObjectReference{
+										Name: configmapName,
+									},
+									Items: []apiv1.KeyToPath{
+										{
+											Key:  "grafana.ini",
+											Path: "grafana.ini",
+										},
+										{
+											Key:  "datasources.yaml",
+											Path: "provisioning/datasources/datasources.yaml",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name: "grafana-dashboards",
+							VolumeSource: apiv1.VolumeSource{
+								ConfigMap: &apiv1.ConfigMapVolumeSource{
+									LocalObjectReference: apiv1.LocalObjectReference{
+										Name: dashboardConfigmapName,
+									},
+								},
+							},
+						},
+						{
+							Name: "linkerd-identity-end-entity",
+							VolumeSource: apiv1.VolumeSource{
+								EmptyDir: &apiv1.EmptyDirVolumeSource{
+									Medium: apiv1.StorageMediumMemory,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) containers() []apiv1.Container {
+	grafanaConfig := r.Config.Spec.Grafana
+	containers := []apiv1.Container{
+		templates.DefaultProxyContainer(r.Config),
+		{
+			Name:            "grafana",
+			Image:           *grafanaConfig.Image,
+			ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
+			Env: append([]apiv1.EnvVar{
+				{
+					Name:  "GF_PATHS_DATA",
+					Value: "/data",
+				},
+			}, templates.PrometheusCredentialsEnv(r.Config)...),
+			LivenessProbe:  templates.DefaultLivenessProbe("/api/health", 3000, 30, 30),
+			ReadinessProbe: templates.DefaultReadinessProbe("/api/health", 3000, 3, 30),
+			Resources:      *grafanaConfig.Resources,
+			Ports: []apiv1.ContainerPort{
+				templates.DefaultContainerPort("http", 3000),
+			},
+			SecurityContext: &apiv1.SecurityContext{
+				RunAsUser: util.Int64Pointer(472),
+			},
+			VolumeMounts: []apiv1.VolumeMount{
+				{
+					MountPath: "/data",
+					Name:      "data",
+				},
+				{
+					MountPath: "/etc/grafana",
+					Name:      "grafana-config",
+					ReadOnly:  true,
+				},
+				{
+					MountPath: "/etc/grafana/provisioning/dashboards",
+					Name:      "grafana-dashboards",
+					ReadOnly:  true,
+				},
+			},
+			TerminationMessagePath:   apiv1.TerminationMessagePathDefault,
+			TerminationMessagePolicy: apiv1.TerminationMessageReadFile,
+		},
+	}
+	return containers
+}
@@ -0,0 +1,14 @@
+package grafana
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func (r *Reconciler) serviceAccount() runtime.Object {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: templates.ObjectMeta(serviceAccountName, r.labels(), r.Config),
+	}
+}
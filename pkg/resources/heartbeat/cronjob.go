@@ -1,10 +1,8 @@
 package heartbeat
 
 import (
-	"fmt"
-
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
-	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -14,62 +12,53 @@ import (
 )
 
 func (r *Reconciler) cronjob() runtime.Object {
+	heartbeatConfig := r.Config.Spec.Heartbeat
+	annotations := templates.DefaultAnnotations(string(r.Config.Spec.Version))
 	return &v1beta1.CronJob{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      cronjobName,
-			Namespace: r.Config.Namespace,
-			Labels: map[string]string{
-				"app.kubernetes.io/name":             "heartbeat",
-				"app.kubernetes.io/part-of":          "Linkerd",
-				"app.kubernetes.io/version":          "stable-2.8.1",
-				"linkerd.io/control-plane-component": "heartbeat",
-				"linkerd.io/control-plane-ns":        "linkerd",
-			},
-			Annotations: map[string]string{
-				"linkerd.io/created-by": "linkerd/cli stable-2.8.1",
-			},
-		},
+		ObjectMeta: templates.ObjectMetaWithAnnotations(
+			cronjobName,
+			util.MergeMultipleStringMaps(r.deploymentLabels(), r.labels()),
+			annotations,
+			r.Config,
+		),
 		Spec: v1beta1.CronJobSpec{
-			Schedule: "16 8 * * *",
+			Schedule: heartbeatConfig.Schedule,
 			JobTemplate: v1beta1.JobTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"linkerd.io/control-plane-component": "heartbeat",
-						"linkerd.io/workload-ns":             "linkerd",
-					},
-					Annotations: map[string]string{
-						"linkerd.io/created-by": "linkerd/cli stable-2.8.1",
+						"linkerd.io/control-plane-component": componentName,
+						"linkerd.io/workload-ns":             r.Config.Namespace,
 					},
+					Annotations: annotations,
 				},
 				Spec: batchv1.JobSpec{
 					Template: v1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels:      r.labels(),
+							Annotations: util.MergeStringMaps(annotations, heartbeatConfig.PodAnnotations),
+						},
 						Spec: v1.PodSpec{
 							ServiceAccountName: serviceAccountName,
 							RestartPolicy:      v1.RestartPolicyNever,
+							NodeSelector:       heartbeatConfig.NodeSelector,
+							Affinity:           heartbeatConfig.Affinity,
+							Tolerations:        heartbeatConfig.Tolerations,
 							Containers: []v1.Container{
 								{
 									Name:            componentName,
-									Image:           *r.Config.Spec.Controller.Image,
-									ImagePullPolicy: v1.PullIfNotPresent,
+									Image:           *heartbeatConfig.Image,
+									ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
 									Args: []string{
 										"heartbeat",
-										fmt.Sprintf("-prometheus-url=http://linkerd-prometheus.%s.svc.%s:9090", r.Config.Namespace, "cluster.local"),
+										"-prometheus-url=" + templates.PrometheusURL(r.Config),
 										"-controller-namespace=" + r.Config.Namespace,
 										"-log-level=info",
 									},
+									Env: r.env(),
 									SecurityContext: &v1.SecurityContext{
 										RunAsUser: util.Int64Pointer(2103),
 									},
-									Resources: v1.ResourceRequirements{
-										Limits: v1.ResourceList{
-											v1.ResourceCPU:    resource.MustParse("1"),
-											v1.ResourceMemory: resource.MustParse("250Mi"),
-										},
-										Requests: v1.ResourceList{
-											v1.ResourceCPU:    resource.MustParse("100m"),
-											v1.ResourceMemory: resource.MustParse("50Mi"),
-										},
-									},
+									Resources: *heartbeatConfig.Resources,
 								},
 							},
 						},
@@ -79,3 +68,14 @@ func (r *Reconciler) cronjob() runtime.Object {
 		},
 	}
 }
+
+func (r *Reconciler) env() []v1.EnvVar {
+	env := templates.PrometheusURLEnv(r.Config)
+	if r.Config.Spec.Heartbeat.Endpoint != "" {
+		env = append(env, v1.EnvVar{
+			Name:  "HTTPS_PROXY",
+			Value: r.Config.Spec.Heartbeat.Endpoint,
+		})
+	}
+	return env
+}
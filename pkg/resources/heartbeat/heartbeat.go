@@ -7,6 +7,7 @@ import (
 	"github.com/goph/emperror"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -38,6 +39,9 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 
 	desiredState := k8sutil.DesiredStatePresent
+	if !util.PointerToBool(r.Config.Spec.Heartbeat.Enabled) {
+		desiredState = k8sutil.DesiredStateAbsent
+	}
 
 	log.Info("Reconciling")
 
@@ -66,7 +70,7 @@ func (r *Reconciler) labels() map[string]string {
 	}
 }
 
-// deploymentLabels returns the labels used for the deployment of the web component
+// deploymentLabels returns the labels used for the cronjob of the heartbeat component
 func (r *Reconciler) deploymentLabels() map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name":    componentName,
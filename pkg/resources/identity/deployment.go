@@ -39,7 +39,7 @@ func (r *Reconciler) deployment() runtime.Object {
 				Spec: apiv1.PodSpec{
 					ServiceAccountName: serviceAccountName,
 					Containers:         r.containers(),
-					InitContainers:     templates.ProxyInitContainer(),
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
 					Volumes: []apiv1.Volume{
 						{
 							Name: "config",
@@ -115,15 +115,15 @@ func (r *Reconciler) deployment() runtime.Object {
 func (r *Reconciler) containers() []apiv1.Container {
 	identityConfig := r.Config.Spec.Identity
 	containers := []apiv1.Container{
-		templates.DefaultProxyContainer(r.Config.Spec),
+		templates.DefaultProxyContainer(r.Config),
 		{
 			Name:            "identity",
 			Image:           *identityConfig.Image,
 			ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
-			Args: []string{
+			Args: append([]string{
 				"identity",
 				"-log-level=info",
-			},
+			}, templates.TracingArgs(r.Config)...),
 			LivenessProbe:  templates.DefaultLivenessProbe("/ping", 9990, 10, 30),
 			ReadinessProbe: templates.DefaultReadinessProbe("/ready", 9990, 7, 30),
 			Resources:      *identityConfig.Resources,
@@ -0,0 +1,130 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/goph/emperror"
+	apiv1 "k8s.io/api/core/v1"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/certs"
+)
+
+const (
+	// issuerName is the name the proxies expect the identity issuer to be valid for
+	issuerName = "identity.linkerd.cluster.local"
+	// issuerRenewBefore is how long before its expiry the issuer generated from the signing Secret is replaced
+	issuerRenewBefore = 30 * 24 * time.Hour
+)
+
+// SharedCertificates returns the trust anchors referenced by the identity configuration along with the issuer
+// of this cluster, verified to chain to them. It returns nil when no trust anchors are referenced and the self
+// signed certificates are used
+func SharedCertificates(c client.Client, config *linkerdv1alpha1.Linkerd) (*linkerdv1alpha1.SelfSignedCertificates, error) {
+	identityConfig := config.Spec.Identity
+	if identityConfig.TrustAnchors == nil {
+		return nil, nil
+	}
+
+	trustAnchors, err := trustAnchorsPEM(c, config.Namespace, identityConfig.TrustAnchors)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := certs.DecodePEMCertPool(trustAnchors); err != nil {
+		return nil, emperror.Wrap(err, "invalid trust anchors")
+	}
+
+	var crt, key string
+	issuer := identityConfig.Issuer
+	switch {
+	case issuer.ExternalSecret != "" && issuer.SigningSecret != "":
+		return nil, errors.New("only one of the external and signing issuer Secrets can be set")
+	case issuer.ExternalSecret != "":
+		crt, key, err = tlsSecret(c, config.Namespace, issuer.ExternalSecret)
+		if err != nil {
+			return nil, err
+		}
+	case issuer.SigningSecret != "":
+		crt, key, err = issueFromSigningSecret(c, config, trustAnchors)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("the issuer chaining to the trust anchors is not set")
+	}
+
+	if err := certs.VerifyIssuerCertificate(trustAnchors, crt, key, time.Now()); err != nil {
+		return nil, emperror.Wrap(err, "the issuer does not chain to the trust anchors")
+	}
+
+	return &linkerdv1alpha1.SelfSignedCertificates{
+		TrustAnchorsPEM: trustAnchors,
+		CrtPEM:          crt,
+		KeyPEM:          key,
+	}, nil
+}
+
+func trustAnchorsPEM(c client.Client, namespace string, ref *linkerdv1alpha1.TrustAnchorsReference) (string, error) {
+	switch {
+	case ref.Secret != nil:
+		secret := &apiv1.Secret{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.Secret.Name}, secret); err != nil {
+			return "", emperror.WrapWith(err, "could not get the trust anchors", "secret", ref.Secret.Name)
+		}
+		return string(secret.Data[ref.Secret.Key]), nil
+	case ref.ConfigMap != nil:
+		cm := &apiv1.ConfigMap{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.ConfigMap.Name}, cm); err != nil {
+			return "", emperror.WrapWith(err, "could not get the trust anchors", "configmap", ref.ConfigMap.Name)
+		}
+		return cm.Data[ref.ConfigMap.Key], nil
+	}
+	return "", errors.New("the trust anchors reference neither a Secret nor a ConfigMap")
+}
+
+func tlsSecret(c client.Client, namespace, name string) (string, string, error) {
+	secret := &apiv1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return "", "", emperror.WrapWith(err, "could not get the issuer", "secret", name)
+	}
+	return string(secret.Data[apiv1.TLSCertKey]), string(secret.Data[apiv1.TLSPrivateKeyKey]), nil
+}
+
+// issueFromSigningSecret returns the issuer of the identity Secret while it chains to the trust anchors and
+// does not expire soon, or a new one signed by the CA of the signing Secret
+func issueFromSigningSecret(c client.Client, config *linkerdv1alpha1.Linkerd, trustAnchors string) (string, string, error) {
+	current := &apiv1.Secret{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: config.Namespace, Name: secretName}, current)
+	if err != nil && !k8errors.IsNotFound(err) {
+		return "", "", emperror.WrapWith(err, "could not get the identity issuer", "secret", secretName)
+	}
+	if err == nil {
+		crt, key := string(current.Data["crt.pem"]), string(current.Data["key.pem"])
+		if certs.VerifyIssuerCertificate(trustAnchors, crt, key, time.Now().Add(issuerRenewBefore)) == nil {
+			return crt, key, nil
+		}
+	}
+
+	signerCrt, signerKey, err := tlsSecret(c, config.Namespace, config.Spec.Identity.Issuer.SigningSecret)
+	if err != nil {
+		return "", "", err
+	}
+	crt, err := certs.DecodePEMCrt(signerCrt)
+	if err != nil {
+		return "", "", emperror.Wrap(err, "invalid signing certificate")
+	}
+	key, err := certs.DecodePEMKey(signerKey)
+	if err != nil {
+		return "", "", emperror.Wrap(err, "invalid signing key")
+	}
+	issuer, err := certs.GenerateIssuer(certs.Cred{PrivateKey: key, Crt: *crt}, issuerName)
+	if err != nil {
+		return "", "", err
+	}
+	return issuer.Crt.EncodePEM(), issuer.EncodePrivateKeyPEM(), nil
+}
@@ -16,7 +16,7 @@ func (r *Reconciler) serviceAccount() runtime.Object {
 
 func (r *Reconciler) clusterRole() runtime.Object {
 	return &rbacv1.ClusterRole{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleName, r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleName), r.labels(), r.Config),
 		Rules: []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{"authentication.k8s.io"},
@@ -39,11 +39,11 @@ func (r *Reconciler) clusterRole() runtime.Object {
 
 func (r *Reconciler) clusterRoleBinding() runtime.Object {
 	return &rbacv1.ClusterRoleBinding{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleBindingName, r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleBindingName), r.labels(), r.Config),
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "ClusterRole",
-			Name:     clusterRoleName,
+			Name:     r.ResourceName(clusterRoleName),
 		},
 		Subjects: []rbacv1.Subject{
 			{
@@ -0,0 +1,140 @@
+package multicluster
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var gatewayConfig = `events {
+}
+http {
+    server {
+        listen     %d;
+        location %s {
+            return 200 'ok';
+        }
+    }
+}
+`
+
+func (r *Reconciler) gatewayServiceAccount() runtime.Object {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: r.objectMeta(GatewayName, r.labels()),
+	}
+}
+
+func (r *Reconciler) gatewayConfigMap() runtime.Object {
+	gateway := r.Config.Spec.Multicluster.Gateway
+	return &apiv1.ConfigMap{
+		ObjectMeta: r.objectMeta(gatewayConfigName, r.labels()),
+		Data: map[string]string{
+			"nginx.conf": fmt.Sprintf(gatewayConfig, util.PointerToInt32(gateway.ProbePort), gateway.ProbePath),
+		},
+	}
+}
+
+func (r *Reconciler) gatewayDeployment() runtime.Object {
+	gateway := r.Config.Spec.Multicluster.Gateway
+	probePort := util.PointerToInt32(gateway.ProbePort)
+	probe := &apiv1.Probe{
+		Handler: apiv1.Handler{
+			HTTPGet: &apiv1.HTTPGetAction{
+				Path: gateway.ProbePath,
+				Port: *util.IntstrPointer(int(probePort)),
+			},
+		},
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: r.objectMeta(GatewayName, util.MergeStringMaps(r.labels(), r.gatewayLabels())),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: gateway.ReplicaCount,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: r.gatewayLabels(),
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: r.gatewayLabels(),
+					// the proxy of the gateway is added by the injector, in gateway mode
+					Annotations: util.MergeStringMaps(map[string]string{
+						"linkerd.io/inject":                                      "enabled",
+						"config.linkerd.io/enable-gateway":                       "true",
+						"config.linkerd.io/proxy-require-identity-inbound-ports": fmt.Sprintf("%d,%d", util.PointerToInt32(gateway.Port), probePort),
+					}, gateway.PodAnnotations),
+				},
+				Spec: apiv1.PodSpec{
+					ServiceAccountName: GatewayName,
+					NodeSelector:       gateway.NodeSelector,
+					Affinity:           gateway.Affinity,
+					Tolerations:        gateway.Tolerations,
+					Containers: []apiv1.Container{
+						{
+							Name:            "nginx",
+							Image:           *gateway.Image,
+							ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
+							Ports: []apiv1.ContainerPort{
+								templates.DefaultContainerPort("http-port", int(probePort)),
+							},
+							LivenessProbe:  probe,
+							ReadinessProbe: probe,
+							Resources:      *gateway.Resources,
+							VolumeMounts: []apiv1.VolumeMount{
+								{
+									Name:      gatewayConfigName,
+									MountPath: "/etc/nginx",
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []apiv1.Volume{
+						{
+							Name: gatewayConfigName,
+							VolumeSource: apiv1.VolumeSource{
+								ConfigMap: &apiv1.ConfigMapVolumeSource{
+									LocalObjectReference: apiv1.LocalObjectReference{
+										Name: gatewayConfigName,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) gatewayService() runtime.Object {
+	gateway := r.Config.Spec.Multicluster.Gateway
+	objectMeta := r.objectMeta(GatewayName, r.labels())
+	objectMeta.Annotations = util.MergeStringMaps(objectMeta.Annotations, map[string]string{
+		"mirror.linkerd.io/gateway-identity":     r.gatewayIdentity(),
+		"mirror.linkerd.io/probe-period":         strconv.Itoa(int(util.PointerToInt32(gateway.ProbeSeconds))),
+		"mirror.linkerd.io/probe-path":           gateway.ProbePath,
+		"mirror.linkerd.io/multicluster-gateway": "true",
+	})
+	return &apiv1.Service{
+		ObjectMeta: objectMeta,
+		Spec: apiv1.ServiceSpec{
+			Type:     gateway.ServiceType,
+			Selector: r.gatewayLabels(),
+			Ports: []apiv1.ServicePort{
+				templates.DefaultServicePort("incoming-port", int(util.PointerToInt32(gateway.Port)), int(util.PointerToInt32(gateway.Port))),
+				templates.DefaultServicePort("probe-port", int(util.PointerToInt32(gateway.ProbePort)), int(util.PointerToInt32(gateway.ProbePort))),
+			},
+		},
+	}
+}
+
+// gatewayIdentity is the TLS identity the proxies of the other clusters expect from the gateway
+func (r *Reconciler) gatewayIdentity() string {
+	return fmt.Sprintf("%s.%s.serviceaccount.identity.%s.cluster.local", GatewayName, r.Config.Spec.Multicluster.Namespace, r.Config.Namespace)
+}
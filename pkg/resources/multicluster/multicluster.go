@@ -0,0 +1,117 @@
+package multicluster
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+)
+
+const (
+	componentName = "linkerd-gateway"
+	// GatewayName is the name of the Deployment and of the Service of the gateway
+	GatewayName             = "linkerd-gateway"
+	gatewayConfigName       = "linkerd-gateway-config"
+	remoteAccessClusterRole = "linkerd-service-mirror-remote-access"
+	revisionLabel           = "linkerd.io/revision"
+)
+
+// Reconciler .
+type Reconciler struct {
+	resources.Reconciler
+}
+
+// New .
+func New(client client.Client, config *linkerdv1alpha1.Linkerd) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client: client,
+			Config: config,
+		},
+	}
+}
+
+// Reconcile .
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+
+	desiredState := k8sutil.DesiredStatePresent
+	if !r.Config.Spec.Multicluster.Enabled {
+		desiredState = k8sutil.DesiredStateAbsent
+	}
+
+	log.Info("Reconciling")
+
+	objects := []resources.ResourceWithDesiredState{
+		{Resource: r.gatewayServiceAccount, DesiredState: desiredState},
+		{Resource: r.gatewayConfigMap, DesiredState: desiredState},
+		{Resource: r.gatewayDeployment, DesiredState: desiredState},
+		{Resource: r.gatewayService, DesiredState: desiredState},
+		{Resource: r.remoteAccessServiceAccount, DesiredState: desiredState},
+		{Resource: r.remoteAccessClusterRole, DesiredState: desiredState},
+		{Resource: r.remoteAccessClusterRoleBinding, DesiredState: desiredState},
+	}
+	// the namespace is kept when multicluster is disabled, it holds the Links of the users
+	if r.Config.Spec.Multicluster.Enabled {
+		objects = append([]resources.ResourceWithDesiredState{{Resource: r.namespace, DesiredState: desiredState}}, objects...)
+	}
+
+	for _, res := range objects {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+func (r *Reconciler) labels() map[string]string {
+	return map[string]string{
+		"linkerd.io/control-plane-ns": r.Config.Namespace,
+	}
+}
+
+func (r *Reconciler) gatewayLabels() map[string]string {
+	return map[string]string{
+		"app": GatewayName,
+	}
+}
+
+// objectMeta returns the metadata of an object of the multicluster namespace. Owner references cannot
+// cross namespaces, so these objects are removed by disabling multicluster rather than by the garbage collector
+func (r *Reconciler) objectMeta(name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   r.Config.Spec.Multicluster.Namespace,
+		Labels:      labels,
+		Annotations: templates.DefaultAnnotations(string(r.Config.Spec.Version)),
+	}
+}
+
+func (r *Reconciler) namespace() runtime.Object {
+	labels := map[string]string{
+		"linkerd.io/is-control-plane": "false",
+	}
+	// the gateway is injected by the injector of the revision
+	if r.Config.Spec.Revision != "" {
+		labels[revisionLabel] = r.Config.Spec.Revision
+	}
+	return &apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        r.Config.Spec.Multicluster.Namespace,
+			Labels:      labels,
+			Annotations: templates.DefaultAnnotations(string(r.Config.Spec.Version)),
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package multicluster
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// remoteAccessServiceAccount is used by the service mirrors of the other clusters, through the
+// kubeconfig referenced by their Links
+func (r *Reconciler) remoteAccessServiceAccount() runtime.Object {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: r.objectMeta(r.Config.Spec.Multicluster.RemoteMirrorServiceAccountName, r.labels()),
+	}
+}
+
+func (r *Reconciler) remoteAccessClusterRole() runtime.Object {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(remoteAccessClusterRole), r.labels(), r.Config),
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"services", "endpoints"},
+				Verbs:     []string{"list", "get", "watch"},
+			},
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				Verbs:         []string{"get"},
+				ResourceNames: []string{"linkerd-config"},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) remoteAccessClusterRoleBinding() runtime.Object {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(remoteAccessClusterRole), r.labels(), r.Config),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     r.ResourceName(remoteAccessClusterRole),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      r.Config.Spec.Multicluster.RemoteMirrorServiceAccountName,
+				Namespace: r.Config.Spec.Multicluster.Namespace,
+			},
+		},
+	}
+}
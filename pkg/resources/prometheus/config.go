@@ -0,0 +1,405 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	configFileName = "prometheus.yml"
+	configDir      = "/etc/prometheus"
+)
+
+var ruleFileNameRegex = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// retentionSizeRegex matches the sizes accepted by --storage.tsdb.retention.size, prometheus rejects
+// the binary units of the Kubernetes quantities such as Gi
+var retentionSizeRegex = regexp.MustCompile(`^[0-9]+(B|KB|MB|GB|TB|PB|EB)$`)
+
+// config is the subset of the prometheus configuration generated by the operator
+type config struct {
+	Global        globalConfig        `yaml:"global"`
+	RuleFiles     []string            `yaml:"rule_files,omitempty"`
+	ScrapeConfigs []interface{}       `yaml:"scrape_configs"`
+	RemoteWrite   []remoteWriteConfig `yaml:"remote_write,omitempty"`
+}
+
+type globalConfig struct {
+	ScrapeInterval     string            `yaml:"scrape_interval"`
+	ScrapeTimeout      string            `yaml:"scrape_timeout"`
+	EvaluationInterval string            `yaml:"evaluation_interval"`
+	ExternalLabels     map[string]string `yaml:"external_labels,omitempty"`
+}
+
+type scrapeConfig struct {
+	JobName              string               `yaml:"job_name"`
+	Scheme               string               `yaml:"scheme,omitempty"`
+	TLSConfig            *tlsConfig           `yaml:"tls_config,omitempty"`
+	BearerTokenFile      string               `yaml:"bearer_token_file,omitempty"`
+	StaticConfigs        []staticConfig       `yaml:"static_configs,omitempty"`
+	KubernetesSDConfigs  []kubernetesSDConfig `yaml:"kubernetes_sd_configs,omitempty"`
+	RelabelConfigs       []relabelConfig      `yaml:"relabel_configs,omitempty"`
+	MetricRelabelConfigs []relabelConfig      `yaml:"metric_relabel_configs,omitempty"`
+}
+
+type tlsConfig struct {
+	CAFile             string `yaml:"ca_file,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+}
+
+type staticConfig struct {
+	Targets []string `yaml:"targets"`
+}
+
+type kubernetesSDConfig struct {
+	Role       string              `yaml:"role"`
+	Namespaces *namespaceDiscovery `yaml:"namespaces,omitempty"`
+}
+
+type namespaceDiscovery struct {
+	Names []string `yaml:"names"`
+}
+
+type relabelConfig struct {
+	SourceLabels []string `yaml:"source_labels,flow,omitempty"`
+	Action       string   `yaml:"action,omitempty"`
+	Regex        string   `yaml:"regex,omitempty"`
+	TargetLabel  string   `yaml:"target_label,omitempty"`
+	Replacement  string   `yaml:"replacement,omitempty"`
+}
+
+type remoteWriteConfig struct {
+	URL           string `yaml:"url"`
+	RemoteTimeout string `yaml:"remote_timeout,omitempty"`
+}
+
+// ruleFile is used to check the rule files look like prometheus rules
+type ruleFile struct {
+	Groups []yaml.MapSlice `yaml:"groups"`
+}
+
+// config builds the configuration of the bundled prometheus and validates the user provided parts of it
+func (r *Reconciler) config(withRules bool) ([]byte, error) {
+	prometheusConfig := r.Config.Spec.Prometheus
+
+	if _, err := model.ParseDuration(prometheusConfig.ScrapeInterval); err != nil {
+		return nil, errors.Wrapf(err, "invalid scrape interval %q", prometheusConfig.ScrapeInterval)
+	}
+	if _, err := model.ParseDuration(prometheusConfig.Persistence.RetentionTime); err != nil {
+		return nil, errors.Wrapf(err, "invalid retention time %q", prometheusConfig.Persistence.RetentionTime)
+	}
+	if size := prometheusConfig.Persistence.RetentionSize; size != "" && !retentionSizeRegex.MatchString(size) {
+		return nil, errors.Errorf("invalid retention size %q, the units are B, KB, MB, GB, TB, PB and EB", size)
+	}
+	for name := range prometheusConfig.ExternalLabels {
+		if !model.LabelName(name).IsValid() {
+			return nil, errors.Errorf("invalid external label name %q", name)
+		}
+	}
+
+	cfg := config{
+		Global: globalConfig{
+			ScrapeInterval:     prometheusConfig.ScrapeInterval,
+			ScrapeTimeout:      prometheusConfig.ScrapeInterval,
+			EvaluationInterval: prometheusConfig.ScrapeInterval,
+			ExternalLabels:     prometheusConfig.ExternalLabels,
+		},
+	}
+
+	ruleFiles, err := r.ruleFiles()
+	if err != nil {
+		return nil, err
+	}
+	if withRules {
+		cfg.RuleFiles = append(cfg.RuleFiles, fmt.Sprintf("%s/%s", configDir, rulesFileName))
+	}
+	for _, name := range ruleFiles {
+		cfg.RuleFiles = append(cfg.RuleFiles, fmt.Sprintf("%s/%s", configDir, name))
+	}
+
+	jobs := make(map[string]bool)
+	for _, sc := range r.scrapeConfigs() {
+		jobs[sc.JobName] = true
+		cfg.ScrapeConfigs = append(cfg.ScrapeConfigs, sc)
+	}
+	additional, err := additionalScrapeConfigs(prometheusConfig.AdditionalScrapeConfigs)
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range additional {
+		name, err := jobName(sc)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateScrapeConfig(sc); err != nil {
+			return nil, errors.Wrapf(err, "invalid additional scrape config %q", name)
+		}
+		if jobs[name] {
+			return nil, errors.Errorf("duplicated scrape job name %q", name)
+		}
+		jobs[name] = true
+		cfg.ScrapeConfigs = append(cfg.ScrapeConfigs, sc)
+	}
+
+	for _, rw := range prometheusConfig.RemoteWrite {
+		u, err := url.Parse(rw.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errors.Errorf("invalid remote write url %q", rw.URL)
+		}
+		if rw.RemoteTimeout != "" {
+			if _, err := model.ParseDuration(rw.RemoteTimeout); err != nil {
+				return nil, errors.Wrapf(err, "invalid remote timeout %q", rw.RemoteTimeout)
+			}
+		}
+		cfg.RemoteWrite = append(cfg.RemoteWrite, remoteWriteConfig{
+			URL:           rw.URL,
+			RemoteTimeout: rw.RemoteTimeout,
+		})
+	}
+
+	return yaml.Marshal(cfg)
+}
+
+// ruleFiles validates the user provided rule files and returns their names sorted
+func (r *Reconciler) ruleFiles() ([]string, error) {
+	names := make([]string, 0, len(r.Config.Spec.Prometheus.RuleFiles))
+	for name, content := range r.Config.Spec.Prometheus.RuleFiles {
+		if !ruleFileNameRegex.MatchString(name) || name == configFileName || name == rulesFileName {
+			return nil, errors.Errorf("invalid rule file name %q", name)
+		}
+		var rf ruleFile
+		if err := yaml.UnmarshalStrict([]byte(content), &rf); err != nil {
+			return nil, errors.Wrapf(err, "invalid rule file %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// additionalScrapeConfigs parses the YAML list of scrape configs provided by the user.
+// They are kept untyped so that every prometheus option can be used
+func additionalScrapeConfigs(raw string) ([]yaml.MapSlice, error) {
+	var scrapeConfigs []yaml.MapSlice
+	if err := yaml.UnmarshalStrict([]byte(raw), &scrapeConfigs); err != nil {
+		return nil, errors.Wrap(err, "invalid additional scrape configs")
+	}
+	return scrapeConfigs, nil
+}
+
+func jobName(sc yaml.MapSlice) (string, error) {
+	for _, item := range sc {
+		if item.Key == "job_name" {
+			if name, ok := item.Value.(string); ok && name != "" {
+				return name, nil
+			}
+		}
+	}
+	return "", errors.New("additional scrape config without job_name")
+}
+
+// scrapeConfigs returns the scrape jobs of the bundled prometheus
+func (r *Reconciler) scrapeConfigs() []scrapeConfig {
+	return append([]scrapeConfig{
+		{
+			JobName: "prometheus",
+			StaticConfigs: []staticConfig{
+				{Targets: []string{"localhost:9090"}},
+			},
+		},
+		{
+			JobName: "grafana",
+			KubernetesSDConfigs: []kubernetesSDConfig{
+				r.namespacePodDiscovery(),
+			},
+			RelabelConfigs: []relabelConfig{
+				{
+					SourceLabels: []string{"__meta_kubernetes_pod_container_name"},
+					Action:       "keep",
+					Regex:        "^grafana$",
+				},
+			},
+		},
+		// Required for: https://grafana.com/grafana/dashboards/315
+		{
+			JobName: "kubernetes-nodes-cadvisor",
+			Scheme:  "https",
+			TLSConfig: &tlsConfig{
+				CAFile:             "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt",
+				InsecureSkipVerify: true,
+			},
+			BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+			KubernetesSDConfigs: []kubernetesSDConfig{
+				{Role: "node"},
+			},
+			RelabelConfigs: []relabelConfig{
+				{
+					Action: "labelmap",
+					Regex:  "__meta_kubernetes_node_label_(.+)",
+				},
+				{
+					TargetLabel: "__address__",
+					Replacement: "kubernetes.default.svc:443",
+				},
+				{
+					SourceLabels: []string{"__meta_kubernetes_node_name"},
+					Regex:        "(.+)",
+					TargetLabel:  "__metrics_path__",
+					Replacement:  "/api/v1/nodes/$1/proxy/metrics/cadvisor",
+				},
+			},
+			MetricRelabelConfigs: []relabelConfig{
+				{
+					SourceLabels: []string{"__name__"},
+					Regex:        "(container|machine)_(cpu|memory|network|fs)_(.+)",
+					Action:       "keep",
+				},
+				{
+					// unneeded large metric
+					SourceLabels: []string{"__name__"},
+					Regex:        "container_memory_failures_total",
+					Action:       "drop",
+				},
+			},
+		},
+	}, r.linkerdScrapeConfigs()...)
+}
+
+// linkerdScrapeConfigs returns the scrape jobs needed to serve the Linkerd metrics
+func (r *Reconciler) linkerdScrapeConfigs() []scrapeConfig {
+	return []scrapeConfig{
+		{
+			JobName: "linkerd-controller",
+			KubernetesSDConfigs: []kubernetesSDConfig{
+				r.namespacePodDiscovery(),
+			},
+			RelabelConfigs: []relabelConfig{
+				{
+					SourceLabels: []string{
+						"__meta_kubernetes_pod_label_linkerd_io_control_plane_component",
+						"__meta_kubernetes_pod_container_port_name",
+					},
+					Action: "keep",
+					Regex:  "(.*);admin-http$",
+				},
+				{
+					SourceLabels: []string{"__meta_kubernetes_pod_container_name"},
+					Action:       "replace",
+					TargetLabel:  "component",
+				},
+			},
+		},
+		{
+			JobName: "linkerd-service-mirror",
+			KubernetesSDConfigs: []kubernetesSDConfig{
+				{Role: "pod"},
+			},
+			RelabelConfigs: []relabelConfig{
+				{
+					SourceLabels: []string{
+						"__meta_kubernetes_pod_label_linkerd_io_control_plane_component",
+						"__meta_kubernetes_pod_container_port_name",
+					},
+					Action: "keep",
+					Regex:  "linkerd-service-mirror;admin-http$",
+				},
+				{
+					SourceLabels: []string{"__meta_kubernetes_pod_container_name"},
+					Action:       "replace",
+					TargetLabel:  "component",
+				},
+			},
+		},
+		{
+			JobName: "linkerd-proxy",
+			KubernetesSDConfigs: []kubernetesSDConfig{
+				{Role: "pod"},
+			},
+			RelabelConfigs: []relabelConfig{
+				{
+					SourceLabels: []string{
+						"__meta_kubernetes_pod_container_name",
+						"__meta_kubernetes_pod_container_port_name",
+						"__meta_kubernetes_pod_label_linkerd_io_control_plane_ns",
+					},
+					Action: "keep",
+					Regex:  fmt.Sprintf("^linkerd-proxy;linkerd-admin;%s$", r.Config.Namespace),
+				},
+				{
+					SourceLabels: []string{"__meta_kubernetes_namespace"},
+					Action:       "replace",
+					TargetLabel:  "namespace",
+				},
+				{
+					SourceLabels: []string{"__meta_kubernetes_pod_name"},
+					Action:       "replace",
+					TargetLabel:  "pod",
+				},
+				// special case k8s' "job" label, to not interfere with prometheus' "job" label
+				// __meta_kubernetes_pod_label_linkerd_io_proxy_job=foo => k8s_job=foo
+				{
+					SourceLabels: []string{"__meta_kubernetes_pod_label_linkerd_io_proxy_job"},
+					Action:       "replace",
+					TargetLabel:  "k8s_job",
+				},
+				// drop __meta_kubernetes_pod_label_linkerd_io_proxy_job
+				{
+					Action: "labeldrop",
+					Regex:  "__meta_kubernetes_pod_label_linkerd_io_proxy_job",
+				},
+				// __meta_kubernetes_pod_label_linkerd_io_proxy_deployment=foo => deployment=foo
+				{
+					Action: "labelmap",
+					Regex:  "__meta_kubernetes_pod_label_linkerd_io_proxy_(.+)",
+				},
+				// drop all labels that we just made copies of in the previous labelmap
+				{
+					Action: "labeldrop",
+					Regex:  "__meta_kubernetes_pod_label_linkerd_io_proxy_(.+)",
+				},
+				// __meta_kubernetes_pod_label_linkerd_io_foo=bar => foo=bar
+				{
+					Action: "labelmap",
+					Regex:  "__meta_kubernetes_pod_label_linkerd_io_(.+)",
+				},
+				// copy all pod labels to tmp labels
+				{
+					Action:      "labelmap",
+					Regex:       "__meta_kubernetes_pod_label_(.+)",
+					Replacement: "__tmp_pod_label_$1",
+				},
+				// take "linkerd_io_" prefixed labels and copy them without the prefix
+				{
+					Action:      "labelmap",
+					Regex:       "__tmp_pod_label_linkerd_io_(.+)",
+					Replacement: "__tmp_pod_label_$1",
+				},
+				// drop the "linkerd_io_" originals
+				{
+					Action: "labeldrop",
+					Regex:  "__tmp_pod_label_linkerd_io_(.+)",
+				},
+				// copy tmp labels into real labels
+				{
+					Action: "labelmap",
+					Regex:  "__tmp_pod_label_(.+)",
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) namespacePodDiscovery() kubernetesSDConfig {
+	return kubernetesSDConfig{
+		Role: "pod",
+		Namespaces: &namespaceDiscovery{
+			Names: []string{r.Config.Namespace},
+		},
+	}
+}
@@ -0,0 +1,175 @@
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+func newTestReconciler(spec linkerdv1alpha1.PrometheusConfiguration) *Reconciler {
+	if spec.ScrapeInterval == "" {
+		spec.ScrapeInterval = "10s"
+	}
+	if spec.Persistence.RetentionTime == "" {
+		spec.Persistence.RetentionTime = "6h"
+	}
+	return New(nil, nil, &linkerdv1alpha1.Linkerd{
+		ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: "linkerd"},
+		Spec:       linkerdv1alpha1.LinkerdSpec{Prometheus: spec},
+	})
+}
+
+func TestConfig(t *testing.T) {
+	r := newTestReconciler(linkerdv1alpha1.PrometheusConfiguration{
+		ExternalLabels: map[string]string{"cluster": "east"},
+		AdditionalScrapeConfigs: `
+- job_name: node-exporter
+  scrape_interval: 30s
+  scrape_timeout: 10s
+  static_configs:
+  - targets: ["node-exporter:9100"]
+    labels:
+      team: platform
+- job_name: kubelet
+  scheme: https
+  bearer_token_file: /var/run/secrets/kubernetes.io/serviceaccount/token
+  tls_config:
+    insecure_skip_verify: true
+  kubernetes_sd_configs:
+  - role: node
+  relabel_configs:
+  - action: labelmap
+    regex: __meta_kubernetes_node_label_(.+)
+  - source_labels: [__meta_kubernetes_node_name]
+    target_label: node
+  - action: hashmod
+    source_labels: [__address__]
+    target_label: __tmp_hash
+    modulus: 2
+- job_name: files
+  file_sd_configs:
+  - files: [/etc/prometheus/targets.json]
+`,
+		RemoteWrite: []linkerdv1alpha1.PrometheusRemoteWrite{
+			{URL: "https://metrics.example.com/api/v1/write", RemoteTimeout: "30s"},
+		},
+		RuleFiles: map[string]string{
+			"custom_rules.yml": "groups:\n- name: custom\n  rules: []\n",
+		},
+	})
+
+	cfg, err := r.config(false)
+	assert.NoError(t, err)
+
+	var parsed struct {
+		Global struct {
+			ScrapeInterval string            `yaml:"scrape_interval"`
+			ExternalLabels map[string]string `yaml:"external_labels"`
+		} `yaml:"global"`
+		RuleFiles     []string `yaml:"rule_files"`
+		ScrapeConfigs []struct {
+			JobName string `yaml:"job_name"`
+		} `yaml:"scrape_configs"`
+		RemoteWrite []struct {
+			URL string `yaml:"url"`
+		} `yaml:"remote_write"`
+	}
+	assert.NoError(t, yaml.Unmarshal(cfg, &parsed))
+	assert.Equal(t, "10s", parsed.Global.ScrapeInterval)
+	assert.Equal(t, "east", parsed.Global.ExternalLabels["cluster"])
+	assert.Equal(t, []string{"/etc/prometheus/custom_rules.yml"}, parsed.RuleFiles)
+	assert.Len(t, parsed.RemoteWrite, 1)
+
+	jobs := make([]string, 0, len(parsed.ScrapeConfigs))
+	for _, sc := range parsed.ScrapeConfigs {
+		jobs = append(jobs, sc.JobName)
+	}
+	assert.Contains(t, jobs, "linkerd-proxy")
+	assert.Contains(t, jobs, "node-exporter")
+	assert.Contains(t, jobs, "kubelet")
+	assert.Contains(t, jobs, "files")
+}
+
+func TestConfigValidation(t *testing.T) {
+	for name, spec := range map[string]linkerdv1alpha1.PrometheusConfiguration{
+		"scrape interval":   {ScrapeInterval: "often"},
+		"retention time":    {Persistence: linkerdv1alpha1.PrometheusPersistence{RetentionTime: "forever"}},
+		"retention size":    {Persistence: linkerdv1alpha1.PrometheusPersistence{RetentionSize: "5Gi"}},
+		"external label":    {ExternalLabels: map[string]string{"not-valid": "x"}},
+		"malformed jobs":    {AdditionalScrapeConfigs: "job_name: foo"},
+		"missing job name":  {AdditionalScrapeConfigs: "- static_configs: []"},
+		"duplicated job":    {AdditionalScrapeConfigs: "- job_name: linkerd-proxy"},
+		"unknown field":     {AdditionalScrapeConfigs: "- job_name: foo\n  static_config: []"},
+		"scrape timeout":    {AdditionalScrapeConfigs: "- job_name: foo\n  scrape_interval: 10s\n  scrape_timeout: 1m"},
+		"duration":          {AdditionalScrapeConfigs: "- job_name: foo\n  scrape_interval: 10"},
+		"scheme":            {AdditionalScrapeConfigs: "- job_name: foo\n  scheme: ftp"},
+		"credentials":       {AdditionalScrapeConfigs: "- job_name: foo\n  bearer_token: a\n  bearer_token_file: b"},
+		"kubernetes role":   {AdditionalScrapeConfigs: "- job_name: foo\n  kubernetes_sd_configs:\n  - role: deployment"},
+		"static label":      {AdditionalScrapeConfigs: "- job_name: foo\n  static_configs:\n  - targets: [a]\n    labels: {not-valid: x}"},
+		"relabel action":    {AdditionalScrapeConfigs: "- job_name: foo\n  relabel_configs:\n  - action: rename"},
+		"relabel regex":     {AdditionalScrapeConfigs: "- job_name: foo\n  relabel_configs:\n  - action: keep\n    regex: '(('"},
+		"relabel target":    {AdditionalScrapeConfigs: "- job_name: foo\n  relabel_configs:\n  - source_labels: [a]"},
+		"hashmod modulus":   {AdditionalScrapeConfigs: "- job_name: foo\n  relabel_configs:\n  - action: hashmod\n    target_label: a"},
+		"labeldrop":         {AdditionalScrapeConfigs: "- job_name: foo\n  relabel_configs:\n  - action: labeldrop\n    regex: a\n    target_label: b"},
+		"remote write url":  {RemoteWrite: []linkerdv1alpha1.PrometheusRemoteWrite{{URL: "metrics:9090"}}},
+		"rule file name":    {RuleFiles: map[string]string{"../rules.yml": "groups: []"}},
+		"rule file content": {RuleFiles: map[string]string{"rules.yml": "rules: []"}},
+	} {
+		_, err := newTestReconciler(spec).config(false)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestAlertingRules(t *testing.T) {
+	enabled := true
+	errorRate := int32(10)
+	r := newTestReconciler(linkerdv1alpha1.PrometheusConfiguration{
+		Alerts: linkerdv1alpha1.PrometheusAlertsConfiguration{
+			Enabled:                    &enabled,
+			ComponentDownFor:           "1m",
+			FailuresFor:                "10m",
+			CertificateExpiryThreshold: "2h",
+			ProxyErrorRatePercentage:   &errorRate,
+		},
+	})
+
+	rules, err := r.alertingRules()
+	assert.NoError(t, err)
+
+	exprs := make(map[string]string)
+	for _, group := range rules.Groups {
+		for _, rule := range group.Rules {
+			exprs[rule.Alert] = rule.Expr
+		}
+	}
+	assert.Contains(t, exprs["LinkerdProxyCertificateExpiring"], "< 7200")
+	assert.Contains(t, exprs["LinkerdHighProxyErrorRate"], "> 10")
+	assert.Contains(t, exprs, "LinkerdIdentityIssuanceFailures")
+	assert.Contains(t, exprs, "LinkerdProxyInjectorErrors")
+
+	cfg, err := r.config(true)
+	assert.NoError(t, err)
+	assert.Contains(t, string(cfg), "/etc/prometheus/linkerd_rules.yml")
+
+	r.Config.Spec.Prometheus.Alerts.FailuresFor = "soon"
+	_, err = r.alertingRules()
+	assert.Error(t, err)
+}
+
+func TestDeploymentReplicas(t *testing.T) {
+	r := newTestReconciler(linkerdv1alpha1.PrometheusConfiguration{
+		BaseK8sResourceConfiguration: linkerdv1alpha1.BaseK8sResourceConfiguration{ReplicaCount: util.IntPointer(2)},
+	})
+	r.Config.Spec.Controller.ReplicaCount = util.IntPointer(3)
+	assert.Equal(t, util.IntPointer(2), r.replicas(), "the replicas of prometheus, not of the controller")
+
+	r.Config.Spec.Prometheus.Persistence.Enabled = true
+	assert.Equal(t, util.IntPointer(1), r.replicas(), "the pods cannot share the ReadWriteOnce claim")
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, r.strategy().Type)
+}
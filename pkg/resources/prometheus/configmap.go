@@ -6,143 +6,19 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func (r *Reconciler) configmap() runtime.Object {
+func (r *Reconciler) configmap(cfg, rules []byte) runtime.Object {
+	data := map[string]string{
+		configFileName: string(cfg),
+	}
+	if rules != nil {
+		data[rulesFileName] = string(rules)
+	}
+	for name, content := range r.Config.Spec.Prometheus.RuleFiles {
+		data[name] = content
+	}
+
 	return &apiv1.ConfigMap{
 		ObjectMeta: templates.ObjectMetaWithAnnotations(configmapName, r.labels(), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
-		Data: map[string]string{
-			"prometheus.yaml": `
-global:
-  scrape_interval: 10s
-  scrape_timeout: 10s
-  evaluation_interval: 10s
-
-rule_files:
-  - /etc/prometheus/*_rules.yml
-  - /etc/prometheus/*_rules.yaml
-
-scrape_configs:
-  - job_name: "prometheus"
-	static_configs:
-	- targets: ["localhost:9090"]
-
-  - job_name: "grafana"
-	kubernetes_sd_configs:
-	- role: pod
-		namespaces:
-		names: ["linkerd"]
-	relabel_configs:
-	- source_labels:
-		- __meta_kubernetes_pod_container_name
-		action: keep
-		regex: ^grafana$
-
-  #  Required for: https://grafana.com/grafana/dashboards/315
-  - job_name: "kubernetes-nodes-cadvisor"
-	scheme: https
-	tls_config:
-	ca_file: /var/run/secrets/kubernetes.io/serviceaccount/ca.crt
-	insecure_skip_verify: true
-	bearer_token_file: /var/run/secrets/kubernetes.io/serviceaccount/token
-
-	kubernetes_sd_configs:
-	- role: node
-	  relabel_configs:
-	  - action: labelmap
-		regex: __meta_kubernetes_node_label_(.+)
-	  - target_label: __address__
-		replacement: kubernetes.default.svc:443
-	  - source_labels: [__meta_kubernetes_node_name]
-		regex: (.+)
-		target_label: __metrics_path__
-		replacement: /api/v1/nodes/$1/proxy/metrics/cadvisor
-	  metric_relabel_configs:
-	  - source_labels: [__name__]
-		regex: "(container|machine)_(cpu|memory|network|fs)_(.+)"
-		action: keep
-	  - source_labels: [__name__]
-		regex: "container_memory_failures_total" # unneeded large metric
-		action: drop
-
-  - job_name: "linkerd-controller"
-	kubernetes_sd_configs:
-	- role: pod
-		namespaces:
-		names: ["linkerd"]
-	relabel_configs:
-	- source_labels:
-		- __meta_kubernetes_pod_label_linkerd_io_control_plane_component
-		- __meta_kubernetes_pod_container_port_name
-		action: keep
-		regex: (.*);admin-http$
-	- source_labels: [__meta_kubernetes_pod_container_name]
-		action: replace
-		target_label: component
-
-  - job_name: "linkerd-service-mirror"
-	kubernetes_sd_configs:
-	- role: pod
-	relabel_configs:
-	- source_labels:
-		- __meta_kubernetes_pod_label_linkerd_io_control_plane_component
-		- __meta_kubernetes_pod_container_port_name
-		action: keep
-		regex: linkerd-service-mirror;admin-http$
-	- source_labels: [__meta_kubernetes_pod_container_name]
-		action: replace
-		target_label: component
-
-  - job_name: "linkerd-proxy"
-	kubernetes_sd_configs:
-	- role: pod
-	relabel_configs:
-	- source_labels:
-		- __meta_kubernetes_pod_container_name
-		- __meta_kubernetes_pod_container_port_name
-		- __meta_kubernetes_pod_label_linkerd_io_control_plane_ns
-		action: keep
-		regex: ^linkerd-proxy;linkerd-admin;linkerd$
-	- source_labels: [__meta_kubernetes_namespace]
-		action: replace
-		target_label: namespace
-	- source_labels: [__meta_kubernetes_pod_name]
-		action: replace
-		target_label: pod
-	# special case k8s' "job" label, to not interfere with prometheus' "job"
-	# label
-	# __meta_kubernetes_pod_label_linkerd_io_proxy_job=foo =>
-	# k8s_job=foo
-	- source_labels: [__meta_kubernetes_pod_label_linkerd_io_proxy_job]
-		action: replace
-		target_label: k8s_job
-	# drop __meta_kubernetes_pod_label_linkerd_io_proxy_job
-	- action: labeldrop
-		regex: __meta_kubernetes_pod_label_linkerd_io_proxy_job
-	# __meta_kubernetes_pod_label_linkerd_io_proxy_deployment=foo =>
-	# deployment=foo
-	- action: labelmap
-		regex: __meta_kubernetes_pod_label_linkerd_io_proxy_(.+)
-	# drop all labels that we just made copies of in the previous labelmap
-	- action: labeldrop
-		regex: __meta_kubernetes_pod_label_linkerd_io_proxy_(.+)
-	# __meta_kubernetes_pod_label_linkerd_io_foo=bar =>
-	# foo=bar
-	- action: labelmap
-		regex: __meta_kubernetes_pod_label_linkerd_io_(.+)
-	# Copy all pod labels to tmp labels
-	- action: labelmap
-		regex: __meta_kubernetes_pod_label_(.+)
-		replacement: __tmp_pod_label_$1
-	# Take "linkerd_io_" prefixed labels and copy them without the prefix
-	- action: labelmap
-		regex: __tmp_pod_label_linkerd_io_(.+)
-		replacement: __tmp_pod_label_$1
-	# Drop the "linkerd_io_" originals
-	- action: labeldrop
-		regex: __tmp_pod_label_linkerd_io_(.+)
-	# Copy tmp labels into real labels
-	- action: labelmap
-		regex: __tmp_pod_label_(.+)
-`,
-		},
+		Data:       data,
 	}
 }
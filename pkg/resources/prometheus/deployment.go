@@ -1,6 +1,8 @@
 package prometheus
 
 import (
+	"fmt"
+
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +16,13 @@ import (
 
 func (r *Reconciler) deployment() runtime.Object {
 	labels := util.MergeStringMaps(r.labels(), r.deploymentLabels())
+	var podSecurityContext *apiv1.PodSecurityContext
+	if r.Config.Spec.Prometheus.Persistence.Enabled {
+		// make the claim writable by the prometheus user
+		podSecurityContext = &apiv1.PodSecurityContext{
+			FSGroup: util.Int64Pointer(65534),
+		}
+	}
 	return &appsv1.Deployment{
 		ObjectMeta: templates.ObjectMetaWithAnnotations(
 			deploymentName,
@@ -22,13 +31,8 @@ func (r *Reconciler) deployment() runtime.Object {
 			r.Config,
 		),
 		Spec: appsv1.DeploymentSpec{
-			Strategy: appsv1.DeploymentStrategy{
-				// TODO: enable only when podAntiAffinity is true
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: &intstr.IntOrString{IntVal: 1},
-				},
-			},
-			Replicas: r.Config.Spec.Controller.ReplicaCount,
+			Strategy: r.strategy(),
+			Replicas: r.replicas(),
 			Selector: &v1.LabelSelector{
 				MatchLabels: r.labels(),
 			},
@@ -40,11 +44,10 @@ func (r *Reconciler) deployment() runtime.Object {
 				Spec: apiv1.PodSpec{
 					ServiceAccountName: serviceAccountName,
 					Containers:         r.containers(),
-					InitContainers:     templates.ProxyInitContainer(),
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
+					SecurityContext:    podSecurityContext,
 					Volumes: []apiv1.Volume{
-						{
-							Name: "data",
-						},
+						r.dataVolume(),
 						{
 							Name: "config",
 							VolumeSource: apiv1.VolumeSource{
@@ -80,23 +83,77 @@ func (r *Reconciler) deployment() runtime.Object {
 	}
 }
 
+// replicas returns the replicas of prometheus, a single one with persistence since the pods cannot share
+// the ReadWriteOnce claim
+func (r *Reconciler) replicas() *int32 {
+	if r.Config.Spec.Prometheus.Persistence.Enabled {
+		return util.IntPointer(1)
+	}
+	return r.Config.Spec.Prometheus.ReplicaCount
+}
+
+// strategy returns the deployment strategy. A ReadWriteOnce claim cannot be
+// attached to the old and the new pod at once, so they must not overlap
+func (r *Reconciler) strategy() appsv1.DeploymentStrategy {
+	if r.Config.Spec.Prometheus.Persistence.Enabled {
+		return appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+	}
+	return appsv1.DeploymentStrategy{
+		// TODO: enable only when podAntiAffinity is true
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &intstr.IntOrString{IntVal: 1},
+		},
+	}
+}
+
+func (r *Reconciler) dataVolume() apiv1.Volume {
+	if r.Config.Spec.Prometheus.Persistence.Enabled {
+		return apiv1.Volume{
+			Name: "data",
+			VolumeSource: apiv1.VolumeSource{
+				PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvcName,
+				},
+			},
+		}
+	}
+	return apiv1.Volume{
+		Name: "data",
+		VolumeSource: apiv1.VolumeSource{
+			EmptyDir: &apiv1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func (r *Reconciler) args() []string {
+	persistence := r.Config.Spec.Prometheus.Persistence
+	args := []string{
+		"--storage.tsdb.path=/data",
+		"--storage.tsdb.retention.time=" + persistence.RetentionTime,
+	}
+	if persistence.RetentionSize != "" {
+		args = append(args, "--storage.tsdb.retention.size="+persistence.RetentionSize)
+	}
+	return append(args,
+		fmt.Sprintf("--config.file=%s/%s", configDir, configFileName),
+		"--log.level=info",
+	)
+}
+
 func (r *Reconciler) containers() []apiv1.Container {
 	prometheusConfig := r.Config.Spec.Prometheus
 	containers := []apiv1.Container{
-		templates.DefaultProxyContainer(r.Config.Spec),
+		templates.DefaultProxyContainer(r.Config),
 		{
 			Name:            "prometheus",
 			Image:           *prometheusConfig.Image,
 			ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
-			Args: []string{
-				"--storage.tsdb.path=/data",
-				"--storage.tsdb.retention.time=6h",
-				"--config.file=/etc/prometheus/prometheus.yml",
-				"--log.level=info",
-			},
-			LivenessProbe:  templates.DefaultLivenessProbe("/-/healthy", 9090, 30, 30),
-			ReadinessProbe: templates.DefaultReadinessProbe("/-/ready", 9090, 30, 30),
-			Resources:      *prometheusConfig.Resources,
+			Args:            r.args(),
+			LivenessProbe:   templates.DefaultLivenessProbe("/-/healthy", 9090, 30, 30),
+			ReadinessProbe:  templates.DefaultReadinessProbe("/-/ready", 9090, 30, 30),
+			Resources:       *prometheusConfig.Resources,
 			Ports: []apiv1.ContainerPort{
 				templates.DefaultContainerPort("admin-http", 9090),
 			},
@@ -111,8 +168,7 @@ func (r *Reconciler) containers() []apiv1.Container {
 				{
 					Name:      "prometheus-config",
 					ReadOnly:  true,
-					MountPath: "/etc/prometheus/prometheus.yml",
-					SubPath:   "prometheus.yml",
+					MountPath: configDir,
 				},
 			},
 			TerminationMessagePath:   apiv1.TerminationMessagePathDefault,
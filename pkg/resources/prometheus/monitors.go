@@ -0,0 +1,97 @@
+package prometheus
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	controllerPodMonitorName = "linkerd-controller"
+	proxyPodMonitorName      = "linkerd-proxy"
+)
+
+var podMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PodMonitor",
+}
+
+func (r *Reconciler) monitorLabels() map[string]string {
+	return util.MergeStringMaps(r.labels(), r.Config.Spec.Prometheus.Operator.Labels)
+}
+
+func (r *Reconciler) controllerPodMonitor() runtime.Object {
+	return templates.Unstructured(podMonitorGVK, templates.ObjectMeta(controllerPodMonitorName, r.monitorLabels(), r.Config), map[string]interface{}{
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{r.Config.Namespace},
+		},
+		"selector": map[string]interface{}{
+			"matchExpressions": []interface{}{
+				map[string]interface{}{
+					"key":      "linkerd.io/control-plane-component",
+					"operator": "Exists",
+				},
+			},
+		},
+		"podMetricsEndpoints": []interface{}{
+			map[string]interface{}{
+				"port":        "admin-http",
+				"relabelings": r.relabelings(controllerPodMonitorName),
+			},
+		},
+	})
+}
+
+func (r *Reconciler) proxyPodMonitor() runtime.Object {
+	return templates.Unstructured(podMonitorGVK, templates.ObjectMeta(proxyPodMonitorName, r.monitorLabels(), r.Config), map[string]interface{}{
+		"namespaceSelector": map[string]interface{}{
+			"any": true,
+		},
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				"linkerd.io/control-plane-ns": r.Config.Namespace,
+			},
+		},
+		"podMetricsEndpoints": []interface{}{
+			map[string]interface{}{
+				"port":        "linkerd-admin",
+				"relabelings": r.relabelings(proxyPodMonitorName),
+			},
+		},
+	})
+}
+
+// relabelings converts the relabel configs of the given Linkerd scrape job to the
+// prometheus-operator format, and keeps the job label the Linkerd dashboards query
+func (r *Reconciler) relabelings(job string) []interface{} {
+	relabelings := make([]interface{}, 0)
+	for _, sc := range r.linkerdScrapeConfigs() {
+		if sc.JobName != job {
+			continue
+		}
+		for _, rc := range sc.RelabelConfigs {
+			relabeling := make(map[string]interface{})
+			if len(rc.SourceLabels) > 0 {
+				relabeling["sourceLabels"] = util.EmptyTypedStrSlice(rc.SourceLabels...)
+			}
+			for key, value := range map[string]string{
+				"action":      rc.Action,
+				"regex":       rc.Regex,
+				"targetLabel": rc.TargetLabel,
+				"replacement": rc.Replacement,
+			} {
+				if value != "" {
+					relabeling[key] = value
+				}
+			}
+			relabelings = append(relabelings, relabeling)
+		}
+	}
+	return append(relabelings, map[string]interface{}{
+		"action":      "replace",
+		"targetLabel": "job",
+		"replacement": job,
+	})
+}
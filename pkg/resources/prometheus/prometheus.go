@@ -5,8 +5,13 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
+	"github.com/pkg/errors"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -17,21 +22,25 @@ const (
 	clusterRoleBindingName = "linkerd-prometheus"
 	deploymentName         = "linkerd-prometheus"
 	configmapName          = "linkerd-prometheus-config"
+	scrapeConfigmapName    = "linkerd-prometheus-scrape-config"
+	pvcName                = "linkerd-prometheus-data"
 	serviceName            = "linkerd-prometheus"
 )
 
 // Reconciler .
 type Reconciler struct {
 	resources.Reconciler
+	mapper meta.RESTMapper
 }
 
 // New .
-func New(client client.Client, config *linkerdv1alpha1.Linkerd) *Reconciler {
+func New(client client.Client, mapper meta.RESTMapper, config *linkerdv1alpha1.Linkerd) *Reconciler {
 	return &Reconciler{
 		Reconciler: resources.Reconciler{
 			Client: client,
 			Config: config,
 		},
+		mapper: mapper,
 	}
 }
 
@@ -39,7 +48,42 @@ func New(client client.Client, config *linkerdv1alpha1.Linkerd) *Reconciler {
 func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 
+	prometheusConfig := r.Config.Spec.Prometheus
+	if prometheusConfig.Operator.Enabled && prometheusConfig.URL == "" {
+		return emperror.With(errors.New("url is required when the prometheus-operator integration is enabled"), "component", componentName)
+	}
+
 	desiredState := k8sutil.DesiredStatePresent
+	scrapeConfigDesiredState := k8sutil.DesiredStateAbsent
+	if !prometheusConfig.IsDeployed() {
+		desiredState = k8sutil.DesiredStateAbsent
+		if !prometheusConfig.Operator.Enabled {
+			scrapeConfigDesiredState = k8sutil.DesiredStatePresent
+		}
+	}
+
+	pvcDesiredState := desiredState
+	if !prometheusConfig.Persistence.Enabled {
+		pvcDesiredState = k8sutil.DesiredStateAbsent
+	}
+
+	alertingRules, err := r.alertingRules()
+	if err != nil {
+		return emperror.Wrap(err, "invalid alerting rules")
+	}
+	var rules []byte
+	if alertingRules != nil {
+		// marshalling the typed rules cannot fail
+		rules, _ = yaml.Marshal(alertingRules)
+	}
+
+	var cfg []byte
+	if desiredState == k8sutil.DesiredStatePresent {
+		cfg, err = r.config(rules != nil)
+		if err != nil {
+			return emperror.Wrap(err, "invalid prometheus configuration")
+		}
+	}
 
 	log.Info("Reconciling")
 
@@ -47,9 +91,11 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		{Resource: r.serviceAccount, DesiredState: desiredState},
 		{Resource: r.clusterRole, DesiredState: desiredState},
 		{Resource: r.clusterRoleBinding, DesiredState: desiredState},
-		{Resource: r.configmap, DesiredState: desiredState},
+		{Resource: func() runtime.Object { return r.configmap(cfg, rules) }, DesiredState: desiredState},
+		{Resource: r.persistentVolumeClaim, DesiredState: pvcDesiredState},
 		{Resource: r.deployment, DesiredState: desiredState},
 		{Resource: r.service, DesiredState: desiredState},
+		{Resource: func() runtime.Object { return r.scrapeConfigmap(rules) }, DesiredState: scrapeConfigDesiredState},
 	} {
 		o := res.Resource()
 		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
@@ -58,11 +104,54 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		}
 	}
 
+	if err := r.reconcileMonitors(log, alertingRules); err != nil {
+		return err
+	}
+
 	log.Info("Reconciled")
 
 	return nil
 }
 
+// reconcileMonitors reconciles the prometheus-operator objects, only when their CRDs are installed
+func (r *Reconciler) reconcileMonitors(log logr.Logger, alertingRules *ruleGroups) error {
+	operatorEnabled := r.Config.Spec.Prometheus.Operator.Enabled
+	desiredState := k8sutil.DesiredStateAbsent
+	if operatorEnabled {
+		desiredState = k8sutil.DesiredStatePresent
+	}
+	rulesDesiredState := desiredState
+	if alertingRules == nil {
+		rulesDesiredState = k8sutil.DesiredStateAbsent
+	}
+
+	for _, res := range []struct {
+		gvk schema.GroupVersionKind
+		resources.ResourceWithDesiredState
+	}{
+		{podMonitorGVK, resources.ResourceWithDesiredState{Resource: r.controllerPodMonitor, DesiredState: desiredState}},
+		{podMonitorGVK, resources.ResourceWithDesiredState{Resource: r.proxyPodMonitor, DesiredState: desiredState}},
+		{prometheusRuleGVK, resources.ResourceWithDesiredState{Resource: func() runtime.Object { return r.prometheusRule(alertingRules) }, DesiredState: rulesDesiredState}},
+	} {
+		served, err := k8sutil.IsKindServed(r.mapper, res.gvk)
+		if err != nil {
+			return emperror.WrapWith(err, "could not discover kind support", "kind", res.gvk.Kind)
+		}
+		if !served {
+			if operatorEnabled {
+				log.Info("kind is not served, is prometheus-operator installed?", "kind", res.gvk.Kind)
+			}
+			continue
+		}
+		o := res.Resource()
+		err = k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	return nil
+}
 func (r *Reconciler) labels() map[string]string {
 	return map[string]string{
 		"linkerd.io/control-plane-component": componentName,
@@ -0,0 +1,24 @@
+package prometheus
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func (r *Reconciler) persistentVolumeClaim() runtime.Object {
+	persistence := r.Config.Spec.Prometheus.Persistence
+	pvc := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: templates.ObjectMeta(pvcName, r.labels(), r.Config),
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			AccessModes:      []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce},
+			StorageClassName: persistence.StorageClassName,
+		},
+	}
+	if persistence.Size != nil {
+		pvc.Spec.Resources.Requests = apiv1.ResourceList{
+			apiv1.ResourceStorage: *persistence.Size,
+		}
+	}
+	return pvc
+}
@@ -0,0 +1,153 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	rulesFileName      = "linkerd_rules.yml"
+	prometheusRuleName = "linkerd-control-plane"
+)
+
+var prometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+// controlPlaneComponents are the containers scraped by the linkerd-controller job
+var controlPlaneComponents = []string{"public-api", "destination", "identity", "proxy-injector", "sp-validator", "tap", "web"}
+
+// ruleGroups is both the format of a prometheus rule file and the spec of a PrometheusRule
+type ruleGroups struct {
+	Groups []ruleGroup `yaml:"groups" json:"groups"`
+}
+
+type ruleGroup struct {
+	Name  string `yaml:"name" json:"name"`
+	Rules []rule `yaml:"rules" json:"rules"`
+}
+
+type rule struct {
+	Alert       string            `yaml:"alert" json:"alert"`
+	Expr        string            `yaml:"expr" json:"expr"`
+	For         string            `yaml:"for,omitempty" json:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+// alertingRules returns the control plane alerting rules, or nil when they are disabled
+func (r *Reconciler) alertingRules() (*ruleGroups, error) {
+	alerts := r.Config.Spec.Prometheus.Alerts
+	if !util.PointerToBool(alerts.Enabled) {
+		return nil, nil
+	}
+
+	for name, value := range map[string]string{
+		"component down duration":      alerts.ComponentDownFor,
+		"failures duration":            alerts.FailuresFor,
+		"certificate expiry threshold": alerts.CertificateExpiryThreshold,
+	} {
+		if _, err := model.ParseDuration(value); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s %q", name, value)
+		}
+	}
+	// ParseDuration accepted it, so it cannot fail
+	expiry, _ := model.ParseDuration(alerts.CertificateExpiryThreshold)
+
+	critical := map[string]string{"severity": "critical"}
+	warning := map[string]string{"severity": "warning"}
+
+	componentsDown := make([]rule, 0, len(controlPlaneComponents))
+	for _, component := range controlPlaneComponents {
+		componentsDown = append(componentsDown, rule{
+			Alert:  "LinkerdControlPlaneComponentDown",
+			Expr:   fmt.Sprintf(`absent(up{job="linkerd-controller", component="%s"} == 1)`, component),
+			For:    alerts.ComponentDownFor,
+			Labels: util.MergeStringMaps(critical, map[string]string{"component": component}),
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("The Linkerd %s component is down", component),
+			},
+		})
+	}
+
+	return &ruleGroups{
+		Groups: []ruleGroup{
+			{
+				Name:  "linkerd-control-plane",
+				Rules: componentsDown,
+			},
+			{
+				Name: "linkerd-identity",
+				Rules: []rule{
+					{
+						Alert:  "LinkerdIdentityIssuanceFailures",
+						Expr:   `sum(rate(grpc_server_handled_total{job="linkerd-controller", component="identity", grpc_method="Certify", grpc_code!="OK"}[5m])) > 0`,
+						For:    alerts.FailuresFor,
+						Labels: critical,
+						Annotations: map[string]string{
+							"summary": "The Linkerd identity service fails to issue certificates to the proxies",
+						},
+					},
+					{
+						Alert:  "LinkerdProxyCertificateExpiring",
+						Expr:   fmt.Sprintf(`min by (namespace, pod) (identity_cert_expiration_timestamp_seconds - time()) < %d`, int64(time.Duration(expiry).Seconds())),
+						Labels: warning,
+						Annotations: map[string]string{
+							"summary": "The certificate of the proxy in {{ $labels.namespace }}/{{ $labels.pod }} is about to expire and has not been renewed",
+						},
+					},
+				},
+			},
+			{
+				Name: "linkerd-proxy-injector",
+				Rules: []rule{
+					{
+						// admission requests the injector did not answer with a patch have failed
+						Alert:  "LinkerdProxyInjectorErrors",
+						Expr:   `sum(rate(proxy_inject_admission_requests_total[5m])) - sum(rate(proxy_inject_admission_responses_total[5m])) > 0`,
+						For:    alerts.FailuresFor,
+						Labels: critical,
+						Annotations: map[string]string{
+							"summary": "The Linkerd proxy injector webhook fails to process admission requests",
+						},
+					},
+				},
+			},
+			{
+				Name: "linkerd-proxy",
+				Rules: []rule{
+					{
+						Alert: "LinkerdHighProxyErrorRate",
+						Expr: fmt.Sprintf(`sum by (namespace, deployment) (rate(response_total{direction="inbound", classification="failure"}[5m]))
+  / sum by (namespace, deployment) (rate(response_total{direction="inbound"}[5m])) * 100 > %d`, util.PointerToInt32(alerts.ProxyErrorRatePercentage)),
+						For:    alerts.FailuresFor,
+						Labels: warning,
+						Annotations: map[string]string{
+							"summary": "{{ $value | humanize }}% of the requests to {{ $labels.namespace }}/{{ $labels.deployment }} fail",
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *Reconciler) prometheusRule(rules *ruleGroups) runtime.Object {
+	spec := make(map[string]interface{})
+	if rules != nil {
+		// round trip through JSON to only hold values unstructured objects can deep copy
+		raw, _ := json.Marshal(rules)
+		_ = json.Unmarshal(raw, &spec)
+	}
+	return templates.Unstructured(prometheusRuleGVK, templates.ObjectMeta(prometheusRuleName, r.monitorLabels(), r.Config), spec)
+}
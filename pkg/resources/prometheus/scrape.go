@@ -0,0 +1,24 @@
+package prometheus
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// scrapeConfigmap publishes the scrape configuration and the alerting rules to load into an external prometheus
+func (r *Reconciler) scrapeConfigmap(rules []byte) runtime.Object {
+	// marshalling the typed scrape configs cannot fail
+	scrapeConfigs, _ := yaml.Marshal(r.linkerdScrapeConfigs())
+	data := map[string]string{
+		"scrape_configs.yml": string(scrapeConfigs),
+	}
+	if rules != nil {
+		data[rulesFileName] = string(rules)
+	}
+	return &apiv1.ConfigMap{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(scrapeConfigmapName, r.labels(), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
+		Data:       data,
+	}
+}
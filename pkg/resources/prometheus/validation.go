@@ -0,0 +1,145 @@
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	commonconfig "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+)
+
+// validatedScrapeConfig mirrors the scrape_config of prometheus, so that an invalid additional scrape config
+// is rejected when reconciling rather than crash looping prometheus. The service discoveries other than the
+// static and the kubernetes ones are only checked to be lists of objects
+type validatedScrapeConfig struct {
+	JobName              string                        `yaml:"job_name"`
+	HonorLabels          bool                          `yaml:"honor_labels,omitempty"`
+	HonorTimestamps      *bool                         `yaml:"honor_timestamps,omitempty"`
+	Params               map[string][]string           `yaml:"params,omitempty"`
+	ScrapeInterval       model.Duration                `yaml:"scrape_interval,omitempty"`
+	ScrapeTimeout        model.Duration                `yaml:"scrape_timeout,omitempty"`
+	MetricsPath          string                        `yaml:"metrics_path,omitempty"`
+	Scheme               string                        `yaml:"scheme,omitempty"`
+	SampleLimit          uint                          `yaml:"sample_limit,omitempty"`
+	HTTPClientConfig     commonconfig.HTTPClientConfig `yaml:",inline"`
+	StaticConfigs        []validatedStaticConfig       `yaml:"static_configs,omitempty"`
+	KubernetesSDConfigs  []validatedKubernetesSDConfig `yaml:"kubernetes_sd_configs,omitempty"`
+	RelabelConfigs       []validatedRelabelConfig      `yaml:"relabel_configs,omitempty"`
+	MetricRelabelConfigs []validatedRelabelConfig      `yaml:"metric_relabel_configs,omitempty"`
+	AzureSDConfigs       []map[string]interface{}      `yaml:"azure_sd_configs,omitempty"`
+	ConsulSDConfigs      []map[string]interface{}      `yaml:"consul_sd_configs,omitempty"`
+	DNSSDConfigs         []map[string]interface{}      `yaml:"dns_sd_configs,omitempty"`
+	EC2SDConfigs         []map[string]interface{}      `yaml:"ec2_sd_configs,omitempty"`
+	FileSDConfigs        []map[string]interface{}      `yaml:"file_sd_configs,omitempty"`
+	GCESDConfigs         []map[string]interface{}      `yaml:"gce_sd_configs,omitempty"`
+	MarathonSDConfigs    []map[string]interface{}      `yaml:"marathon_sd_configs,omitempty"`
+	NerveSDConfigs       []map[string]interface{}      `yaml:"nerve_sd_configs,omitempty"`
+	OpenstackSDConfigs   []map[string]interface{}      `yaml:"openstack_sd_configs,omitempty"`
+	ServersetSDConfigs   []map[string]interface{}      `yaml:"serverset_sd_configs,omitempty"`
+	TritonSDConfigs      []map[string]interface{}      `yaml:"triton_sd_configs,omitempty"`
+}
+
+type validatedStaticConfig struct {
+	Targets []string       `yaml:"targets"`
+	Labels  model.LabelSet `yaml:"labels,omitempty"`
+}
+
+type validatedKubernetesSDConfig struct {
+	APIServer        commonconfig.URL              `yaml:"api_server,omitempty"`
+	Role             string                        `yaml:"role"`
+	HTTPClientConfig commonconfig.HTTPClientConfig `yaml:",inline"`
+	Namespaces       *namespaceDiscovery           `yaml:"namespaces,omitempty"`
+	Selectors        []map[string]string           `yaml:"selectors,omitempty"`
+}
+
+type validatedRelabelConfig struct {
+	SourceLabels []model.LabelName `yaml:"source_labels,flow,omitempty"`
+	Separator    *string           `yaml:"separator,omitempty"`
+	Regex        *string           `yaml:"regex,omitempty"`
+	Modulus      uint64            `yaml:"modulus,omitempty"`
+	TargetLabel  string            `yaml:"target_label,omitempty"`
+	Replacement  *string           `yaml:"replacement,omitempty"`
+	Action       string            `yaml:"action,omitempty"`
+}
+
+var kubernetesRoles = map[string]bool{"endpoints": true, "ingress": true, "node": true, "pod": true, "service": true}
+
+// relabelTargetLabel matches the target labels, which can reference the groups of the regex
+var relabelTargetLabel = regexp.MustCompile(`^(?:(?:[a-zA-Z_]|\$(?:\{\w+\}|\w+))+\w*)+$`)
+
+// validateScrapeConfig checks a scrape config the way prometheus does when it loads its configuration
+func validateScrapeConfig(sc yaml.MapSlice) error {
+	raw, err := yaml.Marshal(sc)
+	if err != nil {
+		return err
+	}
+	var c validatedScrapeConfig
+	if err := yaml.UnmarshalStrict(raw, &c); err != nil {
+		return err
+	}
+
+	if c.JobName == "" {
+		return errors.New("job_name is required")
+	}
+	// the inlined configuration is not validated when unmarshalled
+	if err := c.HTTPClientConfig.Validate(); err != nil {
+		return err
+	}
+	switch c.Scheme {
+	case "", "http", "https":
+	default:
+		return errors.Errorf("scheme %q is not http or https", c.Scheme)
+	}
+	if c.ScrapeInterval != 0 && c.ScrapeTimeout > c.ScrapeInterval {
+		return errors.Errorf("scrape_timeout %s is greater than scrape_interval %s", c.ScrapeTimeout, c.ScrapeInterval)
+	}
+	for _, sd := range c.KubernetesSDConfigs {
+		if !kubernetesRoles[sd.Role] {
+			return errors.Errorf("unknown kubernetes_sd_configs role %q", sd.Role)
+		}
+		if err := sd.HTTPClientConfig.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, rc := range append(c.RelabelConfigs, c.MetricRelabelConfigs...) {
+		if err := rc.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c validatedRelabelConfig) validate() error {
+	action := c.Action
+	if action == "" {
+		action = "replace"
+	}
+	if c.Regex != nil {
+		if _, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", *c.Regex)); err != nil {
+			return errors.Wrapf(err, "invalid relabel regex %q", *c.Regex)
+		}
+	}
+	switch action {
+	case "replace":
+		if !relabelTargetLabel.MatchString(c.TargetLabel) {
+			return errors.Errorf("relabel action replace needs a valid target_label, got %q", c.TargetLabel)
+		}
+	case "hashmod":
+		if !model.LabelName(c.TargetLabel).IsValid() {
+			return errors.Errorf("relabel action hashmod needs a valid target_label, got %q", c.TargetLabel)
+		}
+		if c.Modulus == 0 {
+			return errors.New("relabel action hashmod needs a modulus")
+		}
+	case "labeldrop", "labelkeep":
+		if len(c.SourceLabels) > 0 || c.TargetLabel != "" || c.Modulus != 0 || c.Separator != nil || c.Replacement != nil {
+			return errors.Errorf("relabel action %s only takes a regex", action)
+		}
+	case "keep", "drop", "labelmap":
+	default:
+		return errors.Errorf("unknown relabel action %q", c.Action)
+	}
+	return nil
+}
@@ -40,7 +40,7 @@ func (r *Reconciler) deployment() runtime.Object {
 				Spec: apiv1.PodSpec{
 					ServiceAccountName: serviceAccountName,
 					Containers:         r.containers(),
-					InitContainers:     templates.ProxyInitContainer(),
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
 					Volumes: []apiv1.Volume{
 						{
 							Name: "config",
@@ -56,7 +56,7 @@ func (r *Reconciler) deployment() runtime.Object {
 							Name: "tls",
 							VolumeSource: apiv1.VolumeSource{
 								Secret: &apiv1.SecretVolumeSource{
-									SecretName: "linkerd-proxy-injector-tls",
+									SecretName: secretName,
 								},
 							},
 						},
@@ -116,15 +116,15 @@ func (r *Reconciler) deployment() runtime.Object {
 func (r *Reconciler) containers() []apiv1.Container {
 	proxyInjectorConfig := r.Config.Spec.ProxyInjector
 	containers := []apiv1.Container{
-		templates.DefaultProxyContainer(r.Config.Spec),
+		templates.DefaultProxyContainer(r.Config),
 		{
 			Name:            "proxy-injector",
 			Image:           *proxyInjectorConfig.Image,
 			ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
-			Args: []string{
+			Args: append([]string{
 				"proxy-injector",
 				"-log-level=info",
-			},
+			}, templates.TracingArgs(r.Config)...),
 			LivenessProbe:  templates.DefaultLivenessProbe("/ping", 9995, 10, 30),
 			ReadinessProbe: templates.DefaultReadinessProbe("/ready", 9995, 7, 30),
 			Resources:      *proxyInjectorConfig.Resources,
@@ -1,12 +1,17 @@
 package proxyinjector
 
 import (
+	"fmt"
+
 	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/certs"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -19,20 +24,27 @@ const (
 	deploymentName               = "linkerd-proxy-injector"
 	serviceName                  = "linkerd-proxy-injector"
 	secretName                   = "linkerd-proxy-injector-tls"
+	revisionLabel                = "linkerd.io/revision"
+	admissionWebhooksLabel       = "config.linkerd.io/admission-webhooks"
 )
 
 // Reconciler .
 type Reconciler struct {
 	resources.Reconciler
+	mapper meta.RESTMapper
+	// webhookV1 is true when the cluster serves admissionregistration.k8s.io/v1
+	webhookV1   bool
+	certificate *certs.IdentityWithTrustedAnchor
 }
 
 // New .
-func New(client client.Client, config *linkerdv1alpha1.Linkerd) *Reconciler {
+func New(client client.Client, mapper meta.RESTMapper, config *linkerdv1alpha1.Linkerd) *Reconciler {
 	return &Reconciler{
 		Reconciler: resources.Reconciler{
 			Client: client,
 			Config: config,
 		},
+		mapper: mapper,
 	}
 }
 
@@ -44,6 +56,24 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
+	webhookV1, err := k8sutil.IsKindServed(r.mapper, admissionregistrationv1.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration"))
+	if err != nil {
+		return emperror.Wrap(err, "could not discover admissionregistration.k8s.io/v1 support")
+	}
+	r.webhookV1 = webhookV1
+
+	certificate, err := r.ServingCertificate(secretName, r.serviceHost())
+	if err != nil {
+		return err
+	}
+	r.certificate = certificate
+
+	// the pods of the control plane are not injected, the webhook would otherwise wait for itself to be ready
+	err = k8sutil.ReconcileNamespaceLabelsIgnoreNotFound(log, r.Client, r.Config.Namespace, map[string]string{admissionWebhooksLabel: "disabled"}, nil)
+	if err != nil {
+		return emperror.Wrap(err, "failed to exclude the control plane namespace from the webhook")
+	}
+
 	for _, res := range []resources.ResourceWithDesiredState{
 		{Resource: r.mutatingWebhookConfiguration, DesiredState: desiredState},
 		{Resource: r.serviceAccount, DesiredState: desiredState},
@@ -65,6 +95,11 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	return nil
 }
 
+// serviceHost is the name the API server uses to reach the webhook
+func (r *Reconciler) serviceHost() string {
+	return fmt.Sprintf("%s.%s.svc", serviceName, r.Config.Namespace)
+}
+
 func (r *Reconciler) labels() map[string]string {
 	return map[string]string{
 		"linkerd.io/control-plane-component": componentName,
@@ -2,11 +2,8 @@ package proxyinjector
 
 import (
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
-	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	apiv1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
@@ -19,7 +16,7 @@ func (r *Reconciler) serviceAccount() runtime.Object {
 
 func (r *Reconciler) clusterRole() runtime.Object {
 	return &rbacv1.ClusterRole{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleName, r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleName), r.labels(), r.Config),
 		Rules: []rbacv1.PolicyRule{
 			{
 				Verbs:     []string{"create", "patch"},
@@ -52,11 +49,11 @@ func (r *Reconciler) clusterRole() runtime.Object {
 
 func (r *Reconciler) clusterRoleBinding() runtime.Object {
 	return &rbacv1.ClusterRoleBinding{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleBindingName, r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleBindingName), r.labels(), r.Config),
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "ClusterRole",
-			Name:     clusterRoleName,
+			Name:     r.ResourceName(clusterRoleName),
 		},
 		Subjects: []rbacv1.Subject{
 			{
@@ -67,46 +64,3 @@ func (r *Reconciler) clusterRoleBinding() runtime.Object {
 		},
 	}
 }
-
-func (r *Reconciler) mutatingWebhookConfiguration() runtime.Object {
-	ignore := admissionregistrationv1beta1.Ignore
-	none := admissionregistrationv1beta1.SideEffectClassNone
-	return &admissionregistrationv1beta1.MutatingWebhookConfiguration{
-		ObjectMeta: templates.ObjectMetaClusterScope(mutatingWebhookConfiguration, r.labels(), r.Config),
-		Webhooks: []admissionregistrationv1beta1.MutatingWebhook{
-			{
-				AdmissionReviewVersions: []string{"v1beta1"},
-				Name:                    "linkerd-proxy-injector.linkerd.io",
-				NamespaceSelector: &v1.LabelSelector{
-					MatchExpressions: []v1.LabelSelectorRequirement{
-						{
-							Key:      "config.linkerd.io/admission-webhooks",
-							Operator: "NotIn",
-							Values:   []string{"disabled"},
-						},
-					},
-				},
-				ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
-					Service: &admissionregistrationv1beta1.ServiceReference{
-						Name:      "linkerd-proxy-injector",
-						Namespace: r.Config.Namespace,
-						Path:      util.StrPointer("/"),
-					},
-					CABundle: []byte(r.Config.Spec.SelfSignedCertificates.TrustAnchorsPEM),
-				},
-				FailurePolicy: &ignore,
-				SideEffects:   &none,
-				Rules: []admissionregistrationv1beta1.RuleWithOperations{
-					{
-						Operations: []admissionregistrationv1beta1.OperationType{"CREATE"},
-						Rule: admissionregistrationv1beta1.Rule{
-							APIGroups:   []string{""},
-							APIVersions: []string{"v1"},
-							Resources:   []string{"pods"},
-						},
-					},
-				},
-			},
-		},
-	}
-}
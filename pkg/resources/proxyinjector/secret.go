@@ -1,6 +1,7 @@
 package proxyinjector
 
 import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -11,8 +12,9 @@ func (r *Reconciler) secret() runtime.Object {
 	return &apiv1.Secret{
 		ObjectMeta: templates.ObjectMetaWithAnnotations(secretName, r.labels(), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
 		Data: map[string][]byte{
-			"crt.pem": []byte(r.Config.Spec.SelfSignedCertificates.CrtPEM),
-			"key.pem": []byte(r.Config.Spec.SelfSignedCertificates.KeyPEM),
+			resources.ServingCertificateCAKey:  []byte(r.certificate.TrustAnchorsPEM),
+			resources.ServingCertificateCrtKey: []byte(r.certificate.CrtPEM),
+			resources.ServingCertificateKeyKey: []byte(r.certificate.KeyPEM),
 		},
 	}
 }
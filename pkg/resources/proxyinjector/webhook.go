@@ -0,0 +1,145 @@
+package proxyinjector
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/api/equality"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+)
+
+const webhookName = "linkerd-proxy-injector.linkerd.io"
+
+// mutatingWebhookConfiguration uses admissionregistration.k8s.io/v1 when the cluster serves it, v1beta1 otherwise
+func (r *Reconciler) mutatingWebhookConfiguration() runtime.Object {
+	if r.webhookV1 {
+		return r.mutatingWebhookConfigurationV1()
+	}
+	return r.mutatingWebhookConfigurationV1beta1()
+}
+
+func (r *Reconciler) mutatingWebhookConfigurationV1() runtime.Object {
+	proxyInjectorConfig := r.Config.Spec.ProxyInjector
+	none := admissionregistrationv1.SideEffectClassNone
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(mutatingWebhookConfiguration), r.labels(), r.Config),
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				// the injector only answers v1beta1 admission reviews
+				AdmissionReviewVersions: []string{"v1beta1"},
+				Name:                    webhookName,
+				NamespaceSelector:       r.namespaceSelector(),
+				ObjectSelector:          proxyInjectorConfig.ObjectSelector,
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Name:      serviceName,
+						Namespace: r.Config.Namespace,
+						Path:      util.StrPointer("/"),
+					},
+					CABundle: []byte(r.certificate.TrustAnchorsPEM),
+				},
+				FailurePolicy:      &proxyInjectorConfig.FailurePolicy,
+				SideEffects:        &none,
+				TimeoutSeconds:     proxyInjectorConfig.TimeoutSeconds,
+				ReinvocationPolicy: &proxyInjectorConfig.ReinvocationPolicy,
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) mutatingWebhookConfigurationV1beta1() runtime.Object {
+	proxyInjectorConfig := r.Config.Spec.ProxyInjector
+	failurePolicy := admissionregistrationv1beta1.FailurePolicyType(proxyInjectorConfig.FailurePolicy)
+	reinvocationPolicy := admissionregistrationv1beta1.ReinvocationPolicyType(proxyInjectorConfig.ReinvocationPolicy)
+	none := admissionregistrationv1beta1.SideEffectClassNone
+	return &admissionregistrationv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(mutatingWebhookConfiguration), r.labels(), r.Config),
+		Webhooks: []admissionregistrationv1beta1.MutatingWebhook{
+			{
+				AdmissionReviewVersions: []string{"v1beta1"},
+				Name:                    webhookName,
+				NamespaceSelector:       r.namespaceSelector(),
+				ObjectSelector:          proxyInjectorConfig.ObjectSelector,
+				ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+					Service: &admissionregistrationv1beta1.ServiceReference{
+						Name:      serviceName,
+						Namespace: r.Config.Namespace,
+						Path:      util.StrPointer("/"),
+					},
+					CABundle: []byte(r.certificate.TrustAnchorsPEM),
+				},
+				FailurePolicy:      &failurePolicy,
+				SideEffects:        &none,
+				TimeoutSeconds:     proxyInjectorConfig.TimeoutSeconds,
+				ReinvocationPolicy: &reinvocationPolicy,
+				Rules: []admissionregistrationv1beta1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1beta1.OperationType{admissionregistrationv1beta1.Create},
+						Rule: admissionregistrationv1beta1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// namespaceSelector restricts the configured selector to the namespaces of the revision, leaving out the
+// namespaces with disabled admission webhooks such as the one of the control plane
+func (r *Reconciler) namespaceSelector() *v1.LabelSelector {
+	selector := r.Config.Spec.ProxyInjector.NamespaceSelector.DeepCopy()
+	if selector == nil {
+		selector = &v1.LabelSelector{}
+	}
+	disabled := v1.LabelSelectorRequirement{
+		Key:      admissionWebhooksLabel,
+		Operator: v1.LabelSelectorOpNotIn,
+		Values:   []string{"disabled"},
+	}
+	if !containsRequirement(selector.MatchExpressions, disabled) {
+		selector.MatchExpressions = append(selector.MatchExpressions, disabled)
+	}
+	selector.MatchExpressions = append(selector.MatchExpressions, r.revisionSelector())
+	return selector
+}
+
+func containsRequirement(requirements []v1.LabelSelectorRequirement, requirement v1.LabelSelectorRequirement) bool {
+	for _, r := range requirements {
+		if equality.Semantic.DeepEqual(r, requirement) {
+			return true
+		}
+	}
+	return false
+}
+
+// revisionSelector selects the namespaces labeled with the revision of the control plane,
+// or the namespaces without revision label when the control plane has no revision
+func (r *Reconciler) revisionSelector() v1.LabelSelectorRequirement {
+	if r.Config.Spec.Revision == "" {
+		return v1.LabelSelectorRequirement{
+			Key:      revisionLabel,
+			Operator: v1.LabelSelectorOpDoesNotExist,
+		}
+	}
+	return v1.LabelSelectorRequirement{
+		Key:      revisionLabel,
+		Operator: v1.LabelSelectorOpIn,
+		Values:   []string{r.Config.Spec.Revision},
+	}
+}
@@ -0,0 +1,103 @@
+package proxyinjector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/certs"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+func newConfig(t *testing.T, spec linkerdv1alpha1.LinkerdSpec) *linkerdv1alpha1.Linkerd {
+	config := &linkerdv1alpha1.Linkerd{
+		ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: "linkerd"},
+		Spec:       spec,
+	}
+	release, ok := catalog.Lookup("stable-2.8.1")
+	require.True(t, ok)
+	linkerdv1alpha1.SetDefaults(config, release)
+	// set by the operator from the certificates Secret
+	certificates, err := linkerdv1alpha1.GenerateSelfSignedCertificates()
+	require.NoError(t, err)
+	config.Spec.SelfSignedCertificates = certificates
+	return config
+}
+
+func newReconciler(t *testing.T, config *linkerdv1alpha1.Linkerd) *Reconciler {
+	r := New(fake.NewFakeClient(), nil, config)
+	certificate, err := r.ServingCertificate(secretName, r.serviceHost())
+	require.NoError(t, err)
+	r.certificate = certificate
+	return r
+}
+
+func TestMutatingWebhookConfigurationVersion(t *testing.T) {
+	config := newConfig(t, linkerdv1alpha1.LinkerdSpec{Version: "stable-2.8.1"})
+
+	r := newReconciler(t, config)
+	r.webhookV1 = true
+	webhook, ok := r.mutatingWebhookConfiguration().(*admissionregistrationv1.MutatingWebhookConfiguration)
+	require.True(t, ok, "v1 is used when served")
+	assert.Equal(t, admissionregistrationv1.Ignore, *webhook.Webhooks[0].FailurePolicy)
+	assert.Equal(t, int32(10), *webhook.Webhooks[0].TimeoutSeconds)
+	assert.Equal(t, admissionregistrationv1.NeverReinvocationPolicy, *webhook.Webhooks[0].ReinvocationPolicy)
+	assert.Equal(t, []metav1.LabelSelectorRequirement{
+		{Key: "config.linkerd.io/admission-webhooks", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"disabled"}},
+		{Key: revisionLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+	}, webhook.Webhooks[0].NamespaceSelector.MatchExpressions)
+
+	r.webhookV1 = false
+	_, ok = r.mutatingWebhookConfiguration().(*admissionregistrationv1beta1.MutatingWebhookConfiguration)
+	assert.True(t, ok, "v1beta1 is used on older clusters")
+}
+
+func TestMutatingWebhookConfigurationHighAvailability(t *testing.T) {
+	config := newConfig(t, linkerdv1alpha1.LinkerdSpec{
+		Version:          "stable-2.8.1",
+		HighAvailability: util.BoolPointer(true),
+		Revision:         "canary",
+		ProxyInjector: linkerdv1alpha1.ProxyInjectorConfiguration{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"mesh": "true"}},
+		},
+	})
+
+	r := newReconciler(t, config)
+	r.webhookV1 = true
+	webhook := r.mutatingWebhookConfiguration().(*admissionregistrationv1.MutatingWebhookConfiguration)
+	assert.Equal(t, admissionregistrationv1.Fail, *webhook.Webhooks[0].FailurePolicy)
+	assert.Equal(t, map[string]string{"mesh": "true"}, webhook.Webhooks[0].NamespaceSelector.MatchLabels)
+	assert.Equal(t, []metav1.LabelSelectorRequirement{
+		{Key: admissionWebhooksLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"disabled"}},
+		{Key: revisionLabel, Operator: metav1.LabelSelectorOpIn, Values: []string{"canary"}},
+	}, webhook.Webhooks[0].NamespaceSelector.MatchExpressions, "the control plane namespace is never selected")
+	assert.Nil(t, config.Spec.ProxyInjector.NamespaceSelector.MatchExpressions, "the spec is not modified")
+}
+
+func TestMutatingWebhookConfigurationCABundle(t *testing.T) {
+	config := newConfig(t, linkerdv1alpha1.LinkerdSpec{Version: "stable-2.8.1", HighAvailability: util.BoolPointer(true)})
+
+	r := newReconciler(t, config)
+	r.webhookV1 = true
+	webhook := r.mutatingWebhookConfiguration().(*admissionregistrationv1.MutatingWebhookConfiguration)
+	roots, err := certs.DecodePEMCertPool(string(webhook.Webhooks[0].ClientConfig.CABundle))
+	require.NoError(t, err)
+
+	secret := r.secret().(*apiv1.Secret)
+	crt, err := certs.DecodePEMCrt(string(secret.Data[resources.ServingCertificateCrtKey]))
+	require.NoError(t, err)
+	service := webhook.Webhooks[0].ClientConfig.Service
+	assert.NoError(t, crt.Verify(roots, fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)),
+		"the API server trusts the certificate served by the injector")
+	assert.NotEqual(t, config.Spec.SelfSignedCertificates.TrustAnchorsPEM, string(webhook.Webhooks[0].ClientConfig.CABundle))
+}
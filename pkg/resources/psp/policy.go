@@ -1,28 +1,41 @@
 package psp
 
 import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1beta1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func (r *Reconciler) deployment() runtime.Object {
+func (r *Reconciler) podSecurityPolicy() runtime.Object {
+	// proxy-init needs to run as root with NET_ADMIN to set up the iptables rules,
+	// unless the CNI plugin is taking care of it
+	allowedCapabilities := []v1.Capability{
+		"NET_ADMIN",
+		"NET_RAW",
+	}
+	runAsUser := policyv1.RunAsUserStrategyRunAsAny
+	idRange := policyv1.IDRange{
+		Min: int64(1),
+		Max: int64(65535),
+	}
+	if r.Config.Spec.CNI.Enabled {
+		allowedCapabilities = nil
+		runAsUser = policyv1.RunAsUserStrategyMustRunAsNonRoot
+		idRange = policyv1.IDRange{
+			Min: int64(10001),
+			Max: int64(65535),
+		}
+	}
+
 	return &policyv1.PodSecurityPolicy{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:   "linkerd-control-plane",
-			Labels: r.labels(),
-		},
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(policyName), r.labels(), r.Config),
 		Spec: policyv1.PodSecurityPolicySpec{
 			AllowPrivilegeEscalation: util.BoolPointer(false),
 			ReadOnlyRootFilesystem:   true,
-			// TODO: fix - if cniEnabled do the below
-			AllowedCapabilities: []v1.Capability{
-				"NET_ADMIN",
-				"NET_RAW",
-			},
+			AllowedCapabilities:      allowedCapabilities,
 			RequiredDropCapabilities: []v1.Capability{"ALL"},
 			HostNetwork:              false,
 			HostIPC:                  false,
@@ -31,37 +44,15 @@ func (r *Reconciler) deployment() runtime.Object {
 				Rule: policyv1.SELinuxStrategyRunAsAny,
 			},
 			RunAsUser: policyv1.RunAsUserStrategyOptions{
-				// TODO: fix - if cniEnabled do the below
-				// Rule: policyv1.RunAsUserStrategyMustRunAsNonRoot,
-				Rule: policyv1.RunAsUserStrategyRunAsAny,
+				Rule: runAsUser,
 			},
 			SupplementalGroups: policyv1.SupplementalGroupsStrategyOptions{
-				Rule: policyv1.SupplementalGroupsStrategyMustRunAs,
-				Ranges: []policyv1.IDRange{
-					// TODO: fix - if cniEnabled do the below
-					// {
-					// 	Min: int64(10001),
-					// 	Max: int64(65535),
-					// },
-					{
-						Min: int64(1),
-						Max: int64(65535),
-					},
-				},
+				Rule:   policyv1.SupplementalGroupsStrategyMustRunAs,
+				Ranges: []policyv1.IDRange{idRange},
 			},
 			FSGroup: policyv1.FSGroupStrategyOptions{
-				Rule: policyv1.FSGroupStrategyMustRunAs,
-				Ranges: []policyv1.IDRange{
-					// TODO: fix - if cniEnabled do the below
-					// {
-					// 	Min: int64(10001),
-					// 	Max: int64(65535),
-					// },
-					{
-						Min: int64(1),
-						Max: int64(65535),
-					},
-				},
+				Rule:   policyv1.FSGroupStrategyMustRunAs,
+				Ranges: []policyv1.IDRange{idRange},
 			},
 			Volumes: []policyv1.FSType{
 				policyv1.ConfigMap,
@@ -1,8 +1,12 @@
 package psp
 
 import (
+	"sort"
+
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
+	policyv1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 
 	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
@@ -12,22 +16,31 @@ import (
 
 const (
 	componentName   = "linkerd-control-plane"
+	policyName      = "linkerd-control-plane"
 	roleName        = "linkerd-psp"
 	roleBindingName = "linkerd-psp"
+
+	// LevelPrivileged is the Pod Security Admission level of the pods running proxy-init (NET_ADMIN and NET_RAW)
+	// and of the CNI daemonset (host network and paths)
+	LevelPrivileged = "privileged"
+	// LevelBaseline is the Pod Security Admission level of the control plane once the CNI plugin sets up iptables
+	LevelBaseline = "baseline"
 )
 
 // Reconciler .
 type Reconciler struct {
 	resources.Reconciler
+	mapper meta.RESTMapper
 }
 
 // New .
-func New(client client.Client, config *linkerdv1alpha1.Linkerd) *Reconciler {
+func New(client client.Client, mapper meta.RESTMapper, config *linkerdv1alpha1.Linkerd) *Reconciler {
 	return &Reconciler{
 		Reconciler: resources.Reconciler{
 			Client: client,
 			Config: config,
 		},
+		mapper: mapper,
 	}
 }
 
@@ -39,7 +52,33 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
+	pspServed, err := IsPodSecurityPolicyServed(r.mapper)
+	if err != nil {
+		return emperror.Wrap(err, "could not discover PodSecurityPolicy support")
+	}
+	if !pspServed && r.Config.DeletionTimestamp.IsZero() {
+		// PodSecurityPolicy has been removed from the cluster, rely on Pod Security Admission instead
+		log.V(1).Info("PodSecurityPolicy is not served, labelling namespace for Pod Security Admission")
+		err := k8sutil.ReconcileNamespaceLabelsIgnoreNotFound(log, r.Client, r.Config.Namespace, PodSecurityLabels(r.podSecurityLevel()), nil)
+		if err != nil {
+			return emperror.Wrap(err, "failed to reconcile Pod Security Admission labels")
+		}
+		log.Info("Reconciled")
+		return nil
+	}
+
+	// the labels are removed once the control plane is deleted or PodSecurityPolicy is served again
+	err = k8sutil.ReconcileNamespaceLabelsIgnoreNotFound(log, r.Client, r.Config.Namespace, nil, podSecurityLabelKeys())
+	if err != nil {
+		return emperror.Wrap(err, "failed to remove Pod Security Admission labels")
+	}
+	if !pspServed {
+		log.Info("Reconciled")
+		return nil
+	}
+
 	for _, res := range []resources.ResourceWithDesiredState{
+		{Resource: r.podSecurityPolicy, DesiredState: desiredState},
 		{Resource: r.role, DesiredState: desiredState},
 		{Resource: r.roleBinding, DesiredState: desiredState},
 	} {
@@ -55,8 +94,41 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	return nil
 }
 
+// IsPodSecurityPolicyServed checks whether the cluster still serves the PodSecurityPolicy API
+func IsPodSecurityPolicyServed(mapper meta.RESTMapper) (bool, error) {
+	return k8sutil.IsKindServed(mapper, policyv1.SchemeGroupVersion.WithKind("PodSecurityPolicy"))
+}
+
 func (r *Reconciler) labels() map[string]string {
 	return map[string]string{
 		"linkerd.io/control-plane-ns": r.Config.Namespace,
 	}
 }
+
+// podSecurityLevel returns the Pod Security Admission level the pods of the control-plane namespace need
+func (r *Reconciler) podSecurityLevel() string {
+	if r.Config.Spec.CNI.Enabled {
+		return LevelBaseline
+	}
+	return LevelPrivileged
+}
+
+// PodSecurityLabels returns the Pod Security Admission labels enforcing the level on a namespace
+func PodSecurityLabels(level string) map[string]string {
+	return map[string]string{
+		"pod-security.kubernetes.io/enforce":         level,
+		"pod-security.kubernetes.io/enforce-version": "latest",
+		"pod-security.kubernetes.io/audit":           level,
+		"pod-security.kubernetes.io/warn":            level,
+	}
+}
+
+// podSecurityLabelKeys returns the keys of the Pod Security Admission labels
+func podSecurityLabelKeys() []string {
+	keys := make([]string, 0, 4)
+	for key := range PodSecurityLabels("") {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
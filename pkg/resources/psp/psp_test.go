@@ -0,0 +1,69 @@
+package psp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+func mapper(pspServed bool) meta.RESTMapper {
+	m := meta.NewDefaultRESTMapper(nil)
+	if pspServed {
+		m.Add(policyv1.SchemeGroupVersion.WithKind("PodSecurityPolicy"), meta.RESTScopeRoot)
+	}
+	return m
+}
+
+func namespaceLabels(t *testing.T, c client.Client, name string) map[string]string {
+	ns := &corev1.Namespace{}
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Name: name}, ns))
+	return ns.Labels
+}
+
+func TestPodSecurityAdmission(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Labels: map[string]string{"team": "mesh"}}}
+	c := fake.NewFakeClientWithScheme(scheme, ns)
+	config := &linkerdv1alpha1.Linkerd{ObjectMeta: metav1.ObjectMeta{Name: "linkerd", Namespace: "linkerd"}}
+
+	require.NoError(t, New(c, mapper(false), config).Reconcile(logf.Log))
+	labels := namespaceLabels(t, c, "linkerd")
+	assert.Equal(t, LevelPrivileged, labels["pod-security.kubernetes.io/enforce"], "proxy-init needs NET_ADMIN and NET_RAW")
+	assert.Equal(t, LevelPrivileged, labels["pod-security.kubernetes.io/warn"])
+
+	config.Spec.CNI.Enabled = true
+	require.NoError(t, New(c, mapper(false), config).Reconcile(logf.Log))
+	labels = namespaceLabels(t, c, "linkerd")
+	assert.Equal(t, LevelBaseline, labels["pod-security.kubernetes.io/enforce"], "the CNI plugin sets up iptables instead of proxy-init")
+	assert.Equal(t, LevelBaseline, labels["pod-security.kubernetes.io/audit"])
+
+	now := metav1.NewTime(time.Now())
+	config.DeletionTimestamp = &now
+	require.NoError(t, New(c, mapper(false), config).Reconcile(logf.Log))
+	assert.Equal(t, map[string]string{"team": "mesh"}, namespaceLabels(t, c, "linkerd"), "the labels are removed with the control plane")
+}
+
+func TestPodSecurityLabelKeys(t *testing.T) {
+	assert.Equal(t, []string{
+		"pod-security.kubernetes.io/audit",
+		"pod-security.kubernetes.io/enforce",
+		"pod-security.kubernetes.io/enforce-version",
+		"pod-security.kubernetes.io/warn",
+	}, podSecurityLabelKeys())
+}
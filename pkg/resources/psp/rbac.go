@@ -13,7 +13,7 @@ func (r *Reconciler) roleBinding() runtime.Object {
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "Role",
-			Name:     roleBindingName,
+			Name:     roleName,
 		},
 		Subjects: []rbacv1.Subject{
 			{
@@ -21,18 +21,21 @@ func (r *Reconciler) roleBinding() runtime.Object {
 				Name:      "linkerd-controller",
 				Namespace: r.Config.Namespace,
 			},
+			{
+				Kind:      "ServiceAccount",
+				Name:      "linkerd-collector",
+				Namespace: r.Config.Namespace,
+			},
 			{
 				Kind:      "ServiceAccount",
 				Name:      "linkerd-destination",
 				Namespace: r.Config.Namespace,
 			},
-			// TODO: fix with grafana from CRD
 			{
 				Kind:      "ServiceAccount",
-				Name:      "grafana",
+				Name:      "linkerd-grafana",
 				Namespace: r.Config.Namespace,
 			},
-			// TODO: fix with heartbeat from CRD
 			{
 				Kind:      "ServiceAccount",
 				Name:      "linkerd-heartbeat",
@@ -43,6 +46,11 @@ func (r *Reconciler) roleBinding() runtime.Object {
 				Name:      "linkerd-identity",
 				Namespace: r.Config.Namespace,
 			},
+			{
+				Kind:      "ServiceAccount",
+				Name:      "linkerd-jaeger",
+				Namespace: r.Config.Namespace,
+			},
 			{
 				Kind:      "ServiceAccount",
 				Name:      "linkerd-prometheus",
@@ -86,7 +94,7 @@ func (r *Reconciler) role() runtime.Object {
 				APIGroups:     []string{"policy", "extensions"},
 				Resources:     []string{"podsecuritypolicies"},
 				Verbs:         []string{"use"},
-				ResourceNames: []string{"linkerd-control-plane"},
+				ResourceNames: []string{r.ResourceName(policyName)},
 			},
 		},
 	}
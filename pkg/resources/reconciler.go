@@ -3,6 +3,7 @@ package resources
 import (
 	"github.com/go-logr/logr"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
@@ -21,6 +22,18 @@ type ResourceVariationWithDesiredState struct {
 	DesiredState      k8sutil.DesiredState
 }
 
+// The components reconcile namespaced objects in the namespaces of the Linkerd and Link resources, in the
+// linkerd-cni namespace and in kube-system. Their rules make up the manager-namespaced-role, bound in each
+// of these namespaces when WATCH_NAMESPACE restricts the operator
+
+// +kubebuilder:rbac:groups="",resources=configmaps;secrets;services;serviceaccounts;persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=bind;escalate
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
+
 // Reconciler is the object holding the client and the configuration of the operator
 type Reconciler struct {
 	client.Client
@@ -59,3 +72,8 @@ func ResolveVariations(t string, v []ResourceVariationWithDesiredState, desiredS
 	}
 	return resources
 }
+
+// ResourceName returns the name of a cluster-scoped resource of the control plane, suffixed with its revision
+func (r Reconciler) ResourceName(name string) string {
+	return templates.RevisionName(r.Config.Spec, name)
+}
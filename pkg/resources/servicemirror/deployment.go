@@ -0,0 +1,59 @@
+package servicemirror
+
+import (
+	"fmt"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (r *Reconciler) deployment() runtime.Object {
+	mirrorConfig := r.Config.Spec.Multicluster.ServiceMirror
+	return &appsv1.Deployment{
+		ObjectMeta: templates.ObjectMeta(r.name(), util.MergeStringMaps(r.labels(), r.podLabels()), r.link),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: util.IntPointer(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: r.podLabels(),
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: r.podLabels(),
+					Annotations: util.MergeStringMaps(map[string]string{
+						"linkerd.io/inject": "enabled",
+					}, mirrorConfig.PodAnnotations),
+				},
+				Spec: apiv1.PodSpec{
+					ServiceAccountName: r.name(),
+					NodeSelector:       mirrorConfig.NodeSelector,
+					Affinity:           mirrorConfig.Affinity,
+					Tolerations:        mirrorConfig.Tolerations,
+					Containers: []apiv1.Container{
+						{
+							Name:            "service-mirror",
+							Image:           *mirrorConfig.Image,
+							ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
+							Args: []string{
+								"service-mirror",
+								fmt.Sprintf("-log-level=%s", mirrorConfig.LogLevel),
+								fmt.Sprintf("-event-requeue-limit=%d", util.PointerToInt32(mirrorConfig.EventRequeueLimit)),
+								fmt.Sprintf("-namespace=%s", r.link.Namespace),
+								r.link.Spec.TargetClusterName,
+							},
+							Ports: []apiv1.ContainerPort{
+								templates.DefaultContainerPort("admin-http", 9999),
+							},
+							Resources:       *mirrorConfig.Resources,
+							SecurityContext: mirrorConfig.SecurityContext,
+						},
+					},
+				},
+			},
+		},
+	}
+}
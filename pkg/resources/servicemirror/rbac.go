@@ -0,0 +1,85 @@
+package servicemirror
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func (r *Reconciler) serviceAccount() runtime.Object {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: templates.ObjectMeta(r.name(), r.labels(), r.link),
+	}
+}
+
+// clusterRole lets the service mirror create the mirrored services in any namespace
+func (r *Reconciler) clusterRole() runtime.Object {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(r.name()), r.labels(), r.link),
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"endpoints", "services"},
+				Verbs:     []string{"list", "get", "watch", "create", "delete", "update"},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"namespaces"},
+				Verbs:     []string{"create", "list", "get", "watch"},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) clusterRoleBinding() runtime.Object {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(r.name()), r.labels(), r.link),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     r.ResourceName(r.name()),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      r.name(),
+				Namespace: r.link.Namespace,
+			},
+		},
+	}
+}
+
+// role only grants the credentials of the Link
+func (r *Reconciler) role() runtime.Object {
+	return &rbacv1.Role{
+		ObjectMeta: templates.ObjectMeta(r.name(), r.labels(), r.link),
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				Verbs:         []string{"list", "get", "watch"},
+				ResourceNames: []string{r.credentialsSecretName()},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) roleBinding() runtime.Object {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: templates.ObjectMeta(r.name(), r.labels(), r.link),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     r.name(),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      r.name(),
+				Namespace: r.link.Namespace,
+			},
+		},
+	}
+}
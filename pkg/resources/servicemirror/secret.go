@@ -0,0 +1,25 @@
+package servicemirror
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// credentialsSecret is the kubeconfig of the Link, annotated the way the service mirror expects
+func (r *Reconciler) credentialsSecret() runtime.Object {
+	objectMeta := templates.ObjectMeta(r.credentialsSecretName(), r.labels(), r.link)
+	objectMeta.Annotations = map[string]string{
+		"mirror.linkerd.io/cluster-name":          r.link.Spec.TargetClusterName,
+		"mirror.linkerd.io/remote-cluster-domain": r.link.Spec.TargetClusterDomain,
+		"mirror.linkerd.io/remote-cluster-l5d-ns": r.link.Spec.TargetClusterLinkerdNamespace,
+	}
+	return &apiv1.Secret{
+		ObjectMeta: objectMeta,
+		Type:       credentialsSecretType,
+		Data: map[string][]byte{
+			KubeconfigKey: r.kubeconfig,
+		},
+	}
+}
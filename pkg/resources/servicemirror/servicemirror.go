@@ -0,0 +1,98 @@
+package servicemirror
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+)
+
+const (
+	componentName = "linkerd-service-mirror"
+	// KubeconfigKey is the key of the kubeconfig in the credentials Secrets
+	KubeconfigKey = "kubeconfig"
+	// the type of Secret the service mirror reads the credentials of the remote cluster from
+	credentialsSecretType = "mirror.linkerd.io/remote-kubeconfig"
+)
+
+// Reconciler reconciles the service mirror of a Link, its objects are owned by the Link
+type Reconciler struct {
+	resources.Reconciler
+	link       *linkerdv1alpha1.Link
+	kubeconfig []byte
+}
+
+// New .
+func New(client client.Client, config *linkerdv1alpha1.Linkerd, link *linkerdv1alpha1.Link, kubeconfig []byte) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client: client,
+			Config: config,
+		},
+		link:       link,
+		kubeconfig: kubeconfig,
+	}
+}
+
+// Reconcile .
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName, "link", r.link.Name)
+
+	desiredState := k8sutil.DesiredStatePresent
+	if !r.Config.Spec.Multicluster.Enabled {
+		desiredState = k8sutil.DesiredStateAbsent
+	}
+
+	log.Info("Reconciling")
+
+	for _, res := range []resources.ResourceWithDesiredState{
+		{Resource: r.serviceAccount, DesiredState: desiredState},
+		{Resource: r.clusterRole, DesiredState: desiredState},
+		{Resource: r.clusterRoleBinding, DesiredState: desiredState},
+		{Resource: r.role, DesiredState: desiredState},
+		{Resource: r.roleBinding, DesiredState: desiredState},
+		{Resource: r.credentialsSecret, DesiredState: desiredState},
+		{Resource: r.deployment, DesiredState: desiredState},
+	} {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// DeploymentName is the name of the service mirror of the Link
+func DeploymentName(link *linkerdv1alpha1.Link) string {
+	return fmt.Sprintf("%s-%s", componentName, link.Spec.TargetClusterName)
+}
+
+func (r *Reconciler) name() string {
+	return DeploymentName(r.link)
+}
+
+func (r *Reconciler) credentialsSecretName() string {
+	return fmt.Sprintf("cluster-credentials-%s", r.link.Spec.TargetClusterName)
+}
+
+func (r *Reconciler) labels() map[string]string {
+	return map[string]string{
+		"linkerd.io/control-plane-ns":    r.Config.Namespace,
+		"mirror.linkerd.io/cluster-name": r.link.Spec.TargetClusterName,
+	}
+}
+
+func (r *Reconciler) podLabels() map[string]string {
+	return map[string]string{
+		"component": r.name(),
+	}
+}
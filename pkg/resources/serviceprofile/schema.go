@@ -0,0 +1,122 @@
+package serviceprofile
+
+import (
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+)
+
+// schema returns the validation of a ServiceProfile version. v1alpha2 adds the traffic split
+// overrides, v1alpha1 objects converted to it simply have none
+func schema(withOverrides bool) *extensionv1.CustomResourceValidation {
+	spec := extensionv1.JSONSchemaProps{
+		Type:        "object",
+		Description: "Spec is the custom resource spec",
+		Required:    []string{"routes"},
+		Properties: map[string]extensionv1.JSONSchemaProps{
+			"routes": {
+				Type: "array",
+				Items: &extensionv1.JSONSchemaPropsOrArray{
+					Schema: &extensionv1.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"name", "condition"},
+						Properties: map[string]extensionv1.JSONSchemaProps{
+							"name":      {Type: "string"},
+							"condition": match("pathRegex", "method"),
+							"responseClasses": {
+								Type: "array",
+								Items: &extensionv1.JSONSchemaPropsOrArray{
+									Schema: &extensionv1.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"condition"},
+										Properties: map[string]extensionv1.JSONSchemaProps{
+											"condition": responseMatch(),
+											"isFailure": {Type: "boolean"},
+										},
+									},
+								},
+							},
+							"isRetryable": {Type: "boolean"},
+							"timeout":     {Type: "string"},
+						},
+					},
+				},
+			},
+			"retryBudget": {
+				Type:     "object",
+				Required: []string{"minRetriesPerSecond", "retryRatio", "ttl"},
+				Properties: map[string]extensionv1.JSONSchemaProps{
+					"minRetriesPerSecond": {Type: "integer", Format: "int32"},
+					"retryRatio":          {Type: "number", Format: "float"},
+					"ttl":                 {Type: "string"},
+				},
+			},
+		},
+	}
+	if withOverrides {
+		spec.Properties["dstOverrides"] = extensionv1.JSONSchemaProps{
+			Type: "array",
+			Items: &extensionv1.JSONSchemaPropsOrArray{
+				Schema: &extensionv1.JSONSchemaProps{
+					Type:     "object",
+					Required: []string{"authority", "weight"},
+					Properties: map[string]extensionv1.JSONSchemaProps{
+						"authority": {Type: "string"},
+						"weight":    quantity(),
+					},
+				},
+			},
+		}
+	}
+
+	return &extensionv1.CustomResourceValidation{
+		OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]extensionv1.JSONSchemaProps{
+				"spec": spec,
+			},
+		},
+	}
+}
+
+// match returns the schema of a request condition. The nested all, any and not conditions
+// cannot be described by a structural schema, they are kept as is and validated by the proxy
+func match(fields ...string) extensionv1.JSONSchemaProps {
+	nested := extensionv1.JSONSchemaProps{
+		Type:                   "object",
+		XPreserveUnknownFields: util.BoolPointer(true),
+	}
+	props := extensionv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extensionv1.JSONSchemaProps{
+			"all": {Type: "array", Items: &extensionv1.JSONSchemaPropsOrArray{Schema: &nested}},
+			"any": {Type: "array", Items: &extensionv1.JSONSchemaPropsOrArray{Schema: &nested}},
+			"not": nested,
+		},
+	}
+	for _, field := range fields {
+		props.Properties[field] = extensionv1.JSONSchemaProps{Type: "string"}
+	}
+	return props
+}
+
+func responseMatch() extensionv1.JSONSchemaProps {
+	props := match()
+	props.Properties["status"] = extensionv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extensionv1.JSONSchemaProps{
+			"min": statusCode(),
+			"max": statusCode(),
+		},
+	}
+	return props
+}
+
+func statusCode() extensionv1.JSONSchemaProps {
+	min, max := float64(100), float64(599)
+	return extensionv1.JSONSchemaProps{Type: "integer", Format: "int32", Minimum: &min, Maximum: &max}
+}
+
+func quantity() extensionv1.JSONSchemaProps {
+	return extensionv1.JSONSchemaProps{XIntOrString: true}
+}
@@ -6,9 +6,8 @@ import (
 	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 
-	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -57,19 +56,12 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	return nil
 }
 
-func (r *Reconciler) labels() map[string]string {
-	return map[string]string{
-		"linkerd.io/control-plane-ns": r.Config.Namespace,
-	}
-}
-
+// crd is shared by every control plane and not owned by any of them: deleting it would delete the
+// ServiceProfiles of the users. Its metadata names no control plane, so that they all render the same CRD
 func (r *Reconciler) crd() runtime.Object {
 	return &extensionv1.CustomResourceDefinition{
 		ObjectMeta: v1.ObjectMeta{
-			Name:        componentName,
-			Namespace:   r.Config.Namespace,
-			Annotations: templates.DefaultAnnotations(string(r.Config.Spec.Version)),
-			Labels:      r.labels(),
+			Name: componentName,
 		},
 		Spec: extensionv1.CustomResourceDefinitionSpec{
 			Group: "linkerd.io",
@@ -78,13 +70,19 @@ func (r *Reconciler) crd() runtime.Object {
 					Name:    "v1alpha1",
 					Served:  true,
 					Storage: false,
+					Schema:  schema(false),
 				},
 				{
 					Name:    "v1alpha2",
 					Served:  true,
 					Storage: true,
+					Schema:  schema(true),
 				},
 			},
+			// both versions share the same fields, only the apiVersion changes
+			Conversion: &extensionv1.CustomResourceConversion{
+				Strategy: extensionv1.NoneConverter,
+			},
 			Scope: extensionv1.NamespaceScoped,
 			Names: extensionv1.CustomResourceDefinitionNames{
 				Plural:     "serviceprofiles",
@@ -0,0 +1,102 @@
+package spvalidator
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (r *Reconciler) deployment() runtime.Object {
+	spValidatorConfig := r.Config.Spec.SPValidator
+	labels := util.MergeStringMaps(r.labels(), r.deploymentLabels())
+	annotations := templates.DefaultAnnotations(string(r.Config.Spec.Version))
+	return &appsv1.Deployment{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(
+			deploymentName,
+			util.MergeMultipleStringMaps(r.deploymentLabels(), r.labels()),
+			annotations,
+			r.Config,
+		),
+		Spec: appsv1.DeploymentSpec{
+			Strategy: templates.DefaultRollingUpdateStrategy(),
+			Replicas: spValidatorConfig.ReplicaCount,
+			Selector: &v1.LabelSelector{
+				MatchLabels: r.labels(),
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: util.MergeStringMaps(annotations, spValidatorConfig.PodAnnotations),
+				},
+				Spec: apiv1.PodSpec{
+					ServiceAccountName: serviceAccountName,
+					Containers:         r.containers(),
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
+					Volumes: []apiv1.Volume{
+						{
+							Name: "tls",
+							VolumeSource: apiv1.VolumeSource{
+								Secret: &apiv1.SecretVolumeSource{
+									SecretName: secretName,
+								},
+							},
+						},
+						{
+							Name: "linkerd-identity-end-entity",
+							VolumeSource: apiv1.VolumeSource{
+								EmptyDir: &apiv1.EmptyDirVolumeSource{
+									Medium: apiv1.StorageMediumMemory,
+								},
+							},
+						},
+					},
+					NodeSelector: util.MergeStringMaps(map[string]string{
+						"beta.kubernetes.io/os": "linux",
+					}, spValidatorConfig.NodeSelector),
+					Affinity:    spValidatorConfig.Affinity,
+					Tolerations: spValidatorConfig.Tolerations,
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) containers() []apiv1.Container {
+	spValidatorConfig := r.Config.Spec.SPValidator
+	return []apiv1.Container{
+		templates.DefaultProxyContainer(r.Config),
+		{
+			Name:            componentName,
+			Image:           *spValidatorConfig.Image,
+			ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
+			Args: []string{
+				"sp-validator",
+				"-log-level=info",
+			},
+			LivenessProbe:  templates.DefaultLivenessProbe("/ping", 9997, 10, 30),
+			ReadinessProbe: templates.DefaultReadinessProbe("/ready", 9997, 7, 30),
+			Resources:      *spValidatorConfig.Resources,
+			Ports: []apiv1.ContainerPort{
+				templates.DefaultContainerPort("sp-validator", 8443),
+				templates.DefaultContainerPort("admin-http", 9997),
+			},
+			SecurityContext: &apiv1.SecurityContext{
+				RunAsUser: util.Int64Pointer(2103),
+			},
+			VolumeMounts: []apiv1.VolumeMount{
+				{
+					Name:      "tls",
+					ReadOnly:  true,
+					MountPath: "/var/run/linkerd/tls",
+				},
+			},
+			TerminationMessagePath:   apiv1.TerminationMessagePathDefault,
+			TerminationMessagePolicy: apiv1.TerminationMessageReadFile,
+		},
+	}
+}
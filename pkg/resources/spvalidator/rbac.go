@@ -0,0 +1,92 @@
+package spvalidator
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func (r *Reconciler) serviceAccount() runtime.Object {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: templates.ObjectMeta(serviceAccountName, r.labels(), r.Config),
+	}
+}
+
+func (r *Reconciler) clusterRole() runtime.Object {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleName), r.labels(), r.Config),
+		Rules: []rbacv1.PolicyRule{
+			{
+				Verbs:     []string{"list"},
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) clusterRoleBinding() runtime.Object {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleBindingName), r.labels(), r.Config),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     r.ResourceName(clusterRoleName),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      serviceAccountName,
+				Namespace: r.Config.Namespace,
+			},
+		},
+	}
+}
+
+func (r *Reconciler) validatingWebhookConfiguration() runtime.Object {
+	failurePolicy := admissionregistrationv1beta1.FailurePolicyType(r.Config.Spec.SPValidator.FailurePolicy)
+	none := admissionregistrationv1beta1.SideEffectClassNone
+	return &admissionregistrationv1beta1.ValidatingWebhookConfiguration{
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(validatingWebhookConfiguration), r.labels(), r.Config),
+		Webhooks: []admissionregistrationv1beta1.ValidatingWebhook{
+			{
+				AdmissionReviewVersions: []string{"v1beta1"},
+				Name:                    "linkerd-sp-validator.linkerd.io",
+				NamespaceSelector: &v1.LabelSelector{
+					MatchExpressions: []v1.LabelSelectorRequirement{
+						{
+							Key:      "config.linkerd.io/admission-webhooks",
+							Operator: "NotIn",
+							Values:   []string{"disabled"},
+						},
+					},
+				},
+				ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+					Service: &admissionregistrationv1beta1.ServiceReference{
+						Name:      serviceName,
+						Namespace: r.Config.Namespace,
+						Path:      util.StrPointer("/"),
+					},
+					CABundle: []byte(r.certificate.TrustAnchorsPEM),
+				},
+				FailurePolicy: &failurePolicy,
+				SideEffects:   &none,
+				Rules: []admissionregistrationv1beta1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1beta1.OperationType{"CREATE", "UPDATE"},
+						Rule: admissionregistrationv1beta1.Rule{
+							APIGroups:   []string{"linkerd.io"},
+							APIVersions: []string{"v1alpha1", "v1alpha2"},
+							Resources:   []string{"serviceprofiles"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,20 @@
+package spvalidator
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func (r *Reconciler) secret() runtime.Object {
+	return &apiv1.Secret{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(secretName, r.labels(), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
+		Data: map[string][]byte{
+			resources.ServingCertificateCAKey:  []byte(r.certificate.TrustAnchorsPEM),
+			resources.ServingCertificateCrtKey: []byte(r.certificate.CrtPEM),
+			resources.ServingCertificateKeyKey: []byte(r.certificate.KeyPEM),
+		},
+	}
+}
@@ -40,7 +40,7 @@ func (r *Reconciler) deployment() runtime.Object {
 				},
 				Spec: apiv1.PodSpec{
 					Containers:         r.containers(),
-					InitContainers:     templates.ProxyInitContainer(),
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
 					ServiceAccountName: serviceAccountName,
 					Volumes: []apiv1.Volume{
 						{
@@ -77,6 +77,7 @@ func (r *Reconciler) containers() []apiv1.Container {
 		"-controller-namespace=" + objMeta.GetNamespace(),
 		"-log-level=info",
 	}
+	args = append(args, templates.TracingArgs(r.Config)...)
 
 	tapConfig := r.Config.Spec.Tap
 	containers := []apiv1.Container{
@@ -3,7 +3,7 @@ package tap
 import (
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/kube-aggregator/pkg/apis/apiregistration"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 
 	apiv1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -17,7 +17,7 @@ func (r *Reconciler) serviceAccount() runtime.Object {
 
 func (r *Reconciler) clusterRole() runtime.Object {
 	return &rbacv1.ClusterRole{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleName, r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleName), r.labels(), r.Config),
 		Rules: []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{""},
@@ -40,7 +40,7 @@ func (r *Reconciler) clusterRole() runtime.Object {
 
 func (r *Reconciler) clusterRoleAdmin() runtime.Object {
 	return &rbacv1.ClusterRole{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleNameAdmin, r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleNameAdmin), r.labels(), r.Config),
 		Rules: []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{"tap.linkerd.io"},
@@ -53,11 +53,11 @@ func (r *Reconciler) clusterRoleAdmin() runtime.Object {
 
 func (r *Reconciler) clusterRoleBinding() runtime.Object {
 	return &rbacv1.ClusterRoleBinding{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleBindingName, r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleBindingName), r.labels(), r.Config),
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "ClusterRole",
-			Name:     clusterRoleName,
+			Name:     r.ResourceName(clusterRoleName),
 		},
 		Subjects: []rbacv1.Subject{
 			{
@@ -71,7 +71,7 @@ func (r *Reconciler) clusterRoleBinding() runtime.Object {
 
 func (r *Reconciler) clusterRoleBindingAuthDelegator() runtime.Object {
 	return &rbacv1.ClusterRoleBinding{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleBindingNameAuthDelegator, r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(r.ResourceName(clusterRoleBindingNameAuthDelegator), r.labels(), r.Config),
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "ClusterRole",
@@ -89,7 +89,7 @@ func (r *Reconciler) clusterRoleBindingAuthDelegator() runtime.Object {
 
 func (r *Reconciler) roleBindingAuthReader() runtime.Object {
 	return &rbacv1.RoleBinding{
-		ObjectMeta: templates.ObjectMetaNamespace(roleBindingNameAuthReader, "kube-system", r.labels(), r.Config),
+		ObjectMeta: templates.ObjectMetaNamespace(r.ResourceName(roleBindingNameAuthReader), "kube-system", r.labels(), r.Config),
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "Role",
@@ -106,18 +106,18 @@ func (r *Reconciler) roleBindingAuthReader() runtime.Object {
 }
 
 func (r *Reconciler) apiService() runtime.Object {
-	return &apiregistration.APIService{
-		ObjectMeta: templates.ObjectMetaClusterScope(apiServiceName, r.labels(), r.Config),
-		Spec: apiregistration.APIServiceSpec{
+	return &apiregistrationv1.APIService{
+		ObjectMeta: templates.ObjectMetaClusterScope(APIServiceName, r.labels(), r.Config),
+		Spec: apiregistrationv1.APIServiceSpec{
 			Group:                "tap.linkerd.io",
 			Version:              "v1alpha1",
 			GroupPriorityMinimum: int32(1000),
 			VersionPriority:      int32(100),
-			Service: &apiregistration.ServiceReference{
-				Name:      "linkerd-tap",
+			Service: &apiregistrationv1.ServiceReference{
+				Name:      serviceName,
 				Namespace: r.Config.Namespace,
 			},
-			CABundle: []byte(r.Config.Spec.SelfSignedCertificates.CrtPEM),
+			CABundle: []byte(r.certificate.TrustAnchorsPEM),
 		},
 	}
 }
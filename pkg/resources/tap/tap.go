@@ -1,15 +1,21 @@
 package tap
 
 import (
+	"fmt"
+
 	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/certs"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// APIServiceName is fixed by the API group, so the tap API is only registered by the control plane without revision
+const APIServiceName = "v1alpha1.tap.linkerd.io"
+
 const (
 	componentName                       = "tap"
 	serviceAccountName                  = "linkerd-tap"
@@ -18,7 +24,6 @@ const (
 	clusterRoleNameAdmin                = "linkerd-tap-admin"
 	clusterRoleBindingName              = "linkerd-tap"
 	clusterRoleBindingNameAuthDelegator = "linkerd-tap-auth-delegator"
-	apiServiceName                      = "v1alpha1.tap.linkerd.io"
 	deploymentName                      = "linkerd-tap"
 	secretName                          = "linkerd-tap-tls"
 	serviceName                         = "linkerd-tap"
@@ -27,6 +32,7 @@ const (
 // Reconciler .
 type Reconciler struct {
 	resources.Reconciler
+	certificate *certs.IdentityWithTrustedAnchor
 }
 
 // New .
@@ -47,18 +53,29 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
-	for _, res := range []resources.ResourceWithDesiredState{
+	certificate, err := r.ServingCertificate(secretName, r.serviceHost())
+	if err != nil {
+		return err
+	}
+	r.certificate = certificate
+
+	objects := []resources.ResourceWithDesiredState{
 		{Resource: r.serviceAccount, DesiredState: desiredState},
 		{Resource: r.roleBindingAuthReader, DesiredState: desiredState},
 		{Resource: r.clusterRole, DesiredState: desiredState},
 		{Resource: r.clusterRoleAdmin, DesiredState: desiredState},
 		{Resource: r.clusterRoleBinding, DesiredState: desiredState},
 		{Resource: r.clusterRoleBindingAuthDelegator, DesiredState: desiredState},
-		// {Resource: r.apiService, DesiredState: desiredState},
 		{Resource: r.secret, DesiredState: desiredState},
 		{Resource: r.deployment, DesiredState: desiredState},
 		{Resource: r.service, DesiredState: desiredState},
-	} {
+	}
+	// the revisions leave the tap API to the control plane without revision
+	if r.Config.Spec.Revision == "" {
+		objects = append(objects, resources.ResourceWithDesiredState{Resource: r.apiService, DesiredState: desiredState})
+	}
+
+	for _, res := range objects {
 		o := res.Resource()
 		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
 		if err != nil {
@@ -71,6 +88,11 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	return nil
 }
 
+// serviceHost is the name the API server uses to reach the tap API
+func (r *Reconciler) serviceHost() string {
+	return fmt.Sprintf("%s.%s.svc", serviceName, r.Config.Namespace)
+}
+
 func (r *Reconciler) labels() map[string]string {
 	return map[string]string{
 		"linkerd.io/control-plane-component": "tap",
@@ -1,12 +1,18 @@
 package templates
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/catalog"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
 )
 
@@ -17,6 +23,22 @@ func DefaultAnnotations(version string) map[string]string {
 	}
 }
 
+// RevisionName suffixes the name of a cluster-scoped resource of the control plane with the revision of the
+// control plane, if any. The namespaced resources keep their name, the controller installs a single revision
+// per namespace
+func RevisionName(config v1alpha1.LinkerdSpec, name string) string {
+	if config.Revision == "" {
+		return name
+	}
+	return name + "-" + config.Revision
+}
+
+// Release returns the catalog release of the Linkerd version, checked by the controller before reconciling
+func Release(config v1alpha1.LinkerdSpec) catalog.Release {
+	release, _ := catalog.Lookup(string(config.Version))
+	return release
+}
+
 // GetResourcesRequirementsOrDefault sets the new resources constraints or use the defaults
 func GetResourcesRequirementsOrDefault(requirements *apiv1.ResourceRequirements, defaults *apiv1.ResourceRequirements) apiv1.ResourceRequirements {
 	if requirements != nil {
@@ -80,25 +102,49 @@ func DefaultContainerPort(name string, containerPort int) apiv1.ContainerPort {
 	}
 }
 
-// ProxyInitContainer returns the proxy init container definition
-func ProxyInitContainer() []apiv1.Container {
+// ProxyInitContainer returns the proxy init container definition.
+// No init container is needed when the CNI plugin sets up the iptables rules
+func ProxyInitContainer(config v1alpha1.LinkerdSpec) []apiv1.Container {
+	if config.CNI.Enabled {
+		return nil
+	}
+
+	// the proxy control and admin ports, as well as the kubernetes API port, are always skipped
+	inboundPorts := append([]string{"4190", "4191"}, config.ProxyInit.IgnoreInboundPorts...)
+	outboundPorts := append([]string{"443"}, config.ProxyInit.IgnoreOutboundPorts...)
+
+	args := []string{
+		"--incoming-proxy-port",
+		"4143",
+		"--outgoing-proxy-port",
+		"4140",
+		"--proxy-uid",
+		"2102",
+		"--inbound-ports-to-ignore",
+		strings.Join(inboundPorts, ","),
+		"--outbound-ports-to-ignore",
+		strings.Join(outboundPorts, ","),
+	}
+	if len(config.ProxyInit.SkipSubnets) > 0 {
+		args = append(args, "--subnets-to-ignore", strings.Join(config.ProxyInit.SkipSubnets, ","))
+	}
+	// the validation and the controller reject the nft mode when the proxy-init of the release has no flag to
+	// select it
+	if config.ProxyInit.IptablesMode == v1alpha1.IptablesModeNft && Release(config).SupportsIptablesNft() {
+		args = append(args,
+			"--firewall-bin-path",
+			"iptables-nft",
+			"--firewall-save-bin-path",
+			"iptables-nft-save",
+		)
+	}
+
 	initContainers := []apiv1.Container{
 		{
 			Name:            "linkerd-init",
-			Image:           "gcr.io/linkerd-io/proxy-init:v1.3.3",
+			Image:           Release(config).ProxyInitImage(),
 			ImagePullPolicy: apiv1.PullIfNotPresent,
-			Args: []string{
-				"--incoming-proxy-port",
-				"4143",
-				"--outgoing-proxy-port",
-				"4140",
-				"--proxy-uid",
-				"2102",
-				"--inbound-ports-to-ignore",
-				"4190,4191",
-				"--outbound-ports-to-ignore",
-				"443",
-			},
+			Args:            args,
 			Resources: apiv1.ResourceRequirements{
 				Limits: apiv1.ResourceList{
 					apiv1.ResourceCPU:    resource.MustParse("100m"),
@@ -130,10 +176,11 @@ func ProxyInitContainer() []apiv1.Container {
 }
 
 // DefaultProxyContainer returns the Proxy container definition
-func DefaultProxyContainer(config v1alpha1.LinkerdSpec) apiv1.Container {
-	return apiv1.Container{
+func DefaultProxyContainer(config *v1alpha1.Linkerd) apiv1.Container {
+	spec := config.Spec
+	container := apiv1.Container{
 		Name:            "linkerd-proxy",
-		Image:           "gcr.io/linkerd-io/proxy:stable-2.8.1",
+		Image:           Release(spec).ProxyImage(),
 		ImagePullPolicy: apiv1.PullIfNotPresent,
 		Resources: apiv1.ResourceRequirements{
 			Limits: apiv1.ResourceList{
@@ -191,7 +238,7 @@ func DefaultProxyContainer(config v1alpha1.LinkerdSpec) apiv1.Container {
 			},
 			{
 				Name:  "LINKERD2_PROXY_DESTINATION_SVC_ADDR",
-				Value: "linkerd-dst.linkerd.svc.cluster.local:8086",
+				Value: fmt.Sprintf("linkerd-dst.%s.svc.cluster.local:8086", config.Namespace),
 			},
 			{
 				Name:  "LINKERD2_PROXY_DESTINATION_GET_NETWORKS",
@@ -247,7 +294,7 @@ func DefaultProxyContainer(config v1alpha1.LinkerdSpec) apiv1.Container {
 			},
 			{
 				Name:  "LINKERD2_PROXY_IDENTITY_TRUST_ANCHORS",
-				Value: config.SelfSignedCertificates.TrustAnchorsPEM,
+				Value: spec.SelfSignedCertificates.TrustAnchorsPEM,
 			},
 			{
 				Name:  "LINKERD2_PROXY_IDENTITY_TOKEN_FILE",
@@ -267,7 +314,7 @@ func DefaultProxyContainer(config v1alpha1.LinkerdSpec) apiv1.Container {
 			},
 			{
 				Name:  "_l5d_ns",
-				Value: "linkerd",
+				Value: config.Namespace,
 			},
 			{
 				Name:  "_l5d_trustdomain",
@@ -291,4 +338,142 @@ func DefaultProxyContainer(config v1alpha1.LinkerdSpec) apiv1.Container {
 			},
 		},
 	}
+	container.Env = append(container.Env, proxyTracingEnv(spec)...)
+	return container
+}
+
+// proxyTracingEnv returns the environment variables pointing the proxy to the trace collector.
+// They reference _l5d_ns and _l5d_trustdomain, so they must come after them
+func proxyTracingEnv(spec v1alpha1.LinkerdSpec) []apiv1.EnvVar {
+	config := spec.Tracing
+	if !config.Enabled {
+		return nil
+	}
+	if config.Collector.ExternalAddr != "" {
+		env := []apiv1.EnvVar{
+			{
+				Name:  "LINKERD2_PROXY_TRACE_COLLECTOR_SVC_ADDR",
+				Value: config.Collector.ExternalAddr,
+			},
+		}
+		if config.Collector.ExternalIdentity != "" {
+			env = append(env, apiv1.EnvVar{
+				Name:  "LINKERD2_PROXY_TRACE_COLLECTOR_SVC_NAME",
+				Value: config.Collector.ExternalIdentity,
+			})
+		}
+		return env
+	}
+	return []apiv1.EnvVar{
+		{
+			Name:  "LINKERD2_PROXY_TRACE_COLLECTOR_SVC_ADDR",
+			Value: "linkerd-collector.$(_l5d_ns).svc.cluster.local:55678",
+		},
+		{
+			Name:  "LINKERD2_PROXY_TRACE_COLLECTOR_SVC_NAME",
+			Value: "linkerd-collector.$(_l5d_ns).serviceaccount.identity.$(_l5d_ns).$(_l5d_trustdomain)",
+		},
+	}
+}
+
+// TraceCollectorAddr returns the address of the trace collector the control plane exports its spans to
+func TraceCollectorAddr(config *v1alpha1.Linkerd) string {
+	if config.Spec.Tracing.Collector.ExternalAddr != "" {
+		return config.Spec.Tracing.Collector.ExternalAddr
+	}
+	return fmt.Sprintf("linkerd-collector.%s.svc.cluster.local:55678", config.Namespace)
+}
+
+// TracingArgs returns the control plane flags enabling the export of spans
+func TracingArgs(config *v1alpha1.Linkerd) []string {
+	if !config.Spec.Tracing.Enabled {
+		return nil
+	}
+	return []string{"-trace-collector=" + TraceCollectorAddr(config)}
+}
+
+// PrometheusURLSecretName is the name of the Secret holding the URL of the external prometheus with its
+// basic auth credentials, created by the controller component
+const PrometheusURLSecretName = "linkerd-prometheus-url"
+
+// PrometheusURLKey is the key of the URL in the Secret named PrometheusURLSecretName
+const PrometheusURLKey = "url"
+
+// PrometheusBasicAuth returns whether the control plane queries an external prometheus with basic auth
+func PrometheusBasicAuth(config *v1alpha1.Linkerd) bool {
+	credentials := config.Spec.Prometheus.Credentials
+	return !config.Spec.Prometheus.IsDeployed() && credentials != nil && credentials.Type == v1alpha1.PrometheusAuthBasic
+}
+
+// PrometheusURL returns the URL of the prometheus queried by the control plane. With basic auth, the
+// kubelet expands it from the PrometheusURLEnv variable so that the credentials stay out of the pod spec
+func PrometheusURL(config *v1alpha1.Linkerd) string {
+	prometheusConfig := config.Spec.Prometheus
+	if prometheusConfig.IsDeployed() {
+		return fmt.Sprintf("http://linkerd-prometheus.%s.svc.%s:9090", config.Namespace, "cluster.local")
+	}
+	if PrometheusBasicAuth(config) {
+		return "$(PROMETHEUS_URL)"
+	}
+	return prometheusConfig.URL
+}
+
+// PrometheusURLWithCredentials returns the URL with the basic auth credentials, escaped so that any
+// username or password can be used
+func PrometheusURLWithCredentials(rawURL string, username, password string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", errors.Errorf("the URL %q has no scheme or host", rawURL)
+	}
+	u.User = url.UserPassword(username, password)
+	return u.String(), nil
+}
+
+// PrometheusURLEnv returns the environment variable holding the URL of the external prometheus with its
+// basic auth credentials, for the components that only take the URL
+func PrometheusURLEnv(config *v1alpha1.Linkerd) []apiv1.EnvVar {
+	if !PrometheusBasicAuth(config) {
+		return nil
+	}
+	return []apiv1.EnvVar{
+		{
+			Name: "PROMETHEUS_URL",
+			ValueFrom: &apiv1.EnvVarSource{
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{
+						Name: PrometheusURLSecretName,
+					},
+					Key: PrometheusURLKey,
+				},
+			},
+		},
+	}
+}
+
+// PrometheusCredentialsEnv returns the environment variables holding the basic auth credentials of the
+// external prometheus, for grafana that takes them apart from the URL
+func PrometheusCredentialsEnv(config *v1alpha1.Linkerd) []apiv1.EnvVar {
+	if !PrometheusBasicAuth(config) {
+		return nil
+	}
+	fromSecret := func(name, key string) apiv1.EnvVar {
+		return apiv1.EnvVar{
+			Name: name,
+			ValueFrom: &apiv1.EnvVarSource{
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{
+						Name: config.Spec.Prometheus.Credentials.SecretName,
+					},
+					Key: key,
+				},
+			},
+		}
+	}
+	return []apiv1.EnvVar{
+		fromSecret("PROMETHEUS_USERNAME", "username"),
+		fromSecret("PROMETHEUS_PASSWORD", "password"),
+	}
 }
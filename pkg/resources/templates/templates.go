@@ -3,7 +3,9 @@ package templates
 import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
 )
@@ -82,3 +84,20 @@ func ObjectMetaClusterScope(name string, labels map[string]string, config runtim
 		},
 	}
 }
+
+// Unstructured returns an object of a kind the operator has no Go types for.
+// The spec must only hold JSON compatible values ([]interface{} instead of typed slices)
+func Unstructured(gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta, spec map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(objectMeta.Name)
+	u.SetNamespace(objectMeta.Namespace)
+	u.SetLabels(objectMeta.Labels)
+	u.SetAnnotations(objectMeta.Annotations)
+	u.SetOwnerReferences(objectMeta.OwnerReferences)
+	return u
+}
@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (r *Reconciler) collectorDeployment() runtime.Object {
+	collectorConfig := r.Config.Spec.Tracing.Collector
+	labels := r.labels(collectorName)
+	return &appsv1.Deployment{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(
+			collectorName,
+			util.MergeMultipleStringMaps(r.deploymentLabels(collectorName), labels),
+			templates.DefaultAnnotations(string(r.Config.Spec.Version)),
+			r.Config,
+		),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: collectorConfig.ReplicaCount,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      util.MergeStringMaps(labels, r.deploymentLabels(collectorName)),
+					Annotations: util.MergeStringMaps(templates.DefaultAnnotations(string(r.Config.Spec.Version)), collectorConfig.PodAnnotations),
+				},
+				Spec: apiv1.PodSpec{
+					ServiceAccountName: collectorName,
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
+					NodeSelector:       collectorConfig.NodeSelector,
+					Affinity:           collectorConfig.Affinity,
+					Tolerations:        collectorConfig.Tolerations,
+					Containers: []apiv1.Container{
+						templates.DefaultProxyContainer(r.Config),
+						{
+							Name:            "oc-collector",
+							Image:           *collectorConfig.Image,
+							ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
+							Command: []string{
+								"/occollector_linux",
+								"--config=/conf/linkerd-collector-config.yaml",
+							},
+							Env: []apiv1.EnvVar{
+								{
+									Name:  "GOGC",
+									Value: "80",
+								},
+							},
+							LivenessProbe:  templates.DefaultLivenessProbe("/", 13133, 10, 1),
+							ReadinessProbe: templates.DefaultReadinessProbe("/", 13133, 3, 1),
+							Resources:      *collectorConfig.Resources,
+							Ports: []apiv1.ContainerPort{
+								templates.DefaultContainerPort("opencensus", 55678),
+								templates.DefaultContainerPort("zipkin", 9411),
+							},
+							VolumeMounts: []apiv1.VolumeMount{
+								{
+									Name:      "linkerd-collector-config-val",
+									MountPath: "/conf",
+								},
+							},
+							TerminationMessagePath:   apiv1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: apiv1.TerminationMessageReadFile,
+						},
+					},
+					Volumes: []apiv1.Volume{
+						{
+							Name: "linkerd-collector-config-val",
+							VolumeSource: apiv1.VolumeSource{
+								ConfigMap: &apiv1.ConfigMapVolumeSource{
+									LocalObjectReference: apiv1.LocalObjectReference{
+										Name: collectorConfigmapName,
+									},
+									Items: []apiv1.KeyToPath{
+										{
+											Key:  "linkerd-collector-config",
+											Path: "linkerd-collector-config.yaml",
+										},
+									},
+								},
+							},
+						},
+						{
+							Name: "linkerd-identity-end-entity",
+							VolumeSource: apiv1.VolumeSource{
+								EmptyDir: &apiv1.EmptyDirVolumeSource{
+									Medium: apiv1.StorageMediumMemory,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) collectorService() runtime.Object {
+	return &apiv1.Service{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(collectorName, r.labels(collectorName), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
+		Spec: apiv1.ServiceSpec{
+			Type:     apiv1.ServiceTypeClusterIP,
+			Selector: r.labels(collectorName),
+			Ports: []apiv1.ServicePort{
+				templates.DefaultServicePort("opencensus", 55678, 55678),
+				templates.DefaultServicePort("zipkin", 9411, 9411),
+			},
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hoisie/mustache"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var collectorCfg = `receivers:
+  opencensus:
+    port: 55678
+  zipkin:
+    port: 9411
+sampling:
+  mode: head
+  policies:
+    probabilistic:
+      configuration:
+        sampling-percentage: {{samplingPercentage}}
+queued-exporters:
+  jaeger-all-in-one:
+    num-workers: 4
+    queue-size: 100
+    retry-on-failure: true
+    sender-type: jaeger-thrift-http
+    jaeger-thrift-http:
+      collector-endpoint: {{jaegerEndpoint}}
+      timeout: 5s
+`
+
+func (r *Reconciler) collectorConfigmap() runtime.Object {
+	return &apiv1.ConfigMap{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(collectorConfigmapName, r.labels(collectorName), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
+		Data: map[string]string{
+			"linkerd-collector-config": mustache.Render(collectorCfg, map[string]string{
+				"samplingPercentage": strconv.Itoa(int(util.PointerToInt32(r.Config.Spec.Tracing.Collector.SamplingPercentage))),
+				"jaegerEndpoint":     fmt.Sprintf("http://%s.%s.svc.%s:14268/api/traces", jaegerName, r.Config.Namespace, "cluster.local"),
+			}),
+		},
+	}
+}
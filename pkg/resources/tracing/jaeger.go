@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (r *Reconciler) jaegerDeployment() runtime.Object {
+	jaegerConfig := r.Config.Spec.Tracing.Jaeger
+	labels := r.labels(jaegerName)
+	return &appsv1.Deployment{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(
+			jaegerName,
+			util.MergeMultipleStringMaps(r.deploymentLabels(jaegerName), labels),
+			templates.DefaultAnnotations(string(r.Config.Spec.Version)),
+			r.Config,
+		),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: jaegerConfig.ReplicaCount,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      util.MergeStringMaps(labels, r.deploymentLabels(jaegerName)),
+					Annotations: util.MergeStringMaps(templates.DefaultAnnotations(string(r.Config.Spec.Version)), jaegerConfig.PodAnnotations),
+				},
+				Spec: apiv1.PodSpec{
+					ServiceAccountName: jaegerName,
+					InitContainers:     templates.ProxyInitContainer(r.Config.Spec),
+					NodeSelector:       jaegerConfig.NodeSelector,
+					Affinity:           jaegerConfig.Affinity,
+					Tolerations:        jaegerConfig.Tolerations,
+					Containers: []apiv1.Container{
+						templates.DefaultProxyContainer(r.Config),
+						{
+							Name:            "jaeger",
+							Image:           *jaegerConfig.Image,
+							ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
+							Args: []string{
+								"--query.base-path=/jaeger",
+							},
+							ReadinessProbe: templates.DefaultReadinessProbe("/", 14269, 3, 1),
+							Resources:      *jaegerConfig.Resources,
+							Ports: []apiv1.ContainerPort{
+								templates.DefaultContainerPort("collection", 14268),
+								templates.DefaultContainerPort("ui", 16686),
+							},
+							TerminationMessagePath:   apiv1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: apiv1.TerminationMessageReadFile,
+						},
+					},
+					Volumes: []apiv1.Volume{
+						{
+							Name: "linkerd-identity-end-entity",
+							VolumeSource: apiv1.VolumeSource{
+								EmptyDir: &apiv1.EmptyDirVolumeSource{
+									Medium: apiv1.StorageMediumMemory,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Reconciler) jaegerService() runtime.Object {
+	return &apiv1.Service{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(jaegerName, r.labels(jaegerName), templates.DefaultAnnotations(string(r.Config.Spec.Version)), r.Config),
+		Spec: apiv1.ServiceSpec{
+			Type:     apiv1.ServiceTypeClusterIP,
+			Selector: r.labels(jaegerName),
+			Ports: []apiv1.ServicePort{
+				templates.DefaultServicePort("collection", 14268, 14268),
+				templates.DefaultServicePort("ui", 16686, 16686),
+			},
+		},
+	}
+}
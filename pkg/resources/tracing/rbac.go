@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func (r *Reconciler) collectorServiceAccount() runtime.Object {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: templates.ObjectMeta(collectorName, r.labels(collectorName), r.Config),
+	}
+}
+
+func (r *Reconciler) jaegerServiceAccount() runtime.Object {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: templates.ObjectMeta(jaegerName, r.labels(jaegerName), r.Config),
+	}
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
+	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	componentName = "tracing"
+
+	collectorName          = "linkerd-collector"
+	collectorConfigmapName = "linkerd-collector-config"
+	jaegerName             = "linkerd-jaeger"
+)
+
+// Reconciler .
+type Reconciler struct {
+	resources.Reconciler
+}
+
+// New .
+func New(client client.Client, config *linkerdv1alpha1.Linkerd) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client: client,
+			Config: config,
+		},
+	}
+}
+
+// Reconcile .
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+
+	desiredState := k8sutil.DesiredStatePresent
+	if !r.Config.Spec.Tracing.IsDeployed() {
+		desiredState = k8sutil.DesiredStateAbsent
+	}
+
+	log.Info("Reconciling")
+
+	for _, res := range []resources.ResourceWithDesiredState{
+		{Resource: r.collectorServiceAccount, DesiredState: desiredState},
+		{Resource: r.collectorConfigmap, DesiredState: desiredState},
+		{Resource: r.collectorDeployment, DesiredState: desiredState},
+		{Resource: r.collectorService, DesiredState: desiredState},
+		{Resource: r.jaegerServiceAccount, DesiredState: desiredState},
+		{Resource: r.jaegerDeployment, DesiredState: desiredState},
+		{Resource: r.jaegerService, DesiredState: desiredState},
+	} {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+func (r *Reconciler) labels(component string) map[string]string {
+	return map[string]string{
+		"linkerd.io/control-plane-component": component,
+		"linkerd.io/control-plane-ns":        r.Config.Namespace,
+	}
+}
+
+// deploymentLabels returns the labels used for the deployments of the tracing components
+func (r *Reconciler) deploymentLabels(component string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":    component,
+		"app.kubernetes.io/part-of": "Linkerd",
+		"app.kubernetes.io/version": string(r.Config.Spec.Version),
+	}
+}
@@ -6,9 +6,8 @@ import (
 	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/k8sutil"
 	"github.com/spaghettifunk/linkerd2-operator/pkg/resources"
-	"github.com/spaghettifunk/linkerd2-operator/pkg/resources/templates"
 
-	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -57,36 +56,76 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	return nil
 }
 
-func (r *Reconciler) labels() map[string]string {
-	return map[string]string{
-		"linkerd.io/control-plane-ns": r.Config.Namespace,
-	}
-}
-
+// crd is shared by every control plane and not owned by any of them: deleting it would delete the
+// TrafficSplits of the users. Its metadata names no control plane, so that they all render the same CRD
 func (r *Reconciler) crd() runtime.Object {
 	return &extensionv1.CustomResourceDefinition{
 		ObjectMeta: v1.ObjectMeta{
-			Name:        componentName,
-			Namespace:   r.Config.Namespace,
-			Annotations: templates.DefaultAnnotations(string(r.Config.Spec.Version)),
-			Labels:      r.labels(),
+			Name: componentName,
 		},
 		Spec: extensionv1.CustomResourceDefinitionSpec{
-			Group:   "split.smi-spec.io",
-			Version: "v1alpha1",
-			Scope:   extensionv1.NamespaceScoped,
+			Group: "split.smi-spec.io",
+			Versions: []extensionv1.CustomResourceDefinitionVersion{
+				{
+					Name:    "v1alpha1",
+					Served:  true,
+					Storage: true,
+					Schema:  r.schema(),
+					AdditionalPrinterColumns: []extensionv1.CustomResourceColumnDefinition{
+						{
+							Name:        "Service",
+							Type:        "string",
+							Description: "the apex service of this split.",
+							JSONPath:    ".spec.service",
+						},
+					},
+				},
+			},
+			Scope: extensionv1.NamespaceScoped,
 			Names: extensionv1.CustomResourceDefinitionNames{
 				Plural:     "trafficsplits",
 				Singular:   "trafficsplit",
 				Kind:       "TrafficSplit",
 				ShortNames: []string{"ts"},
 			},
-			AdditionalPrinterColumns: []extensionv1.CustomResourceColumnDefinition{
-				{
-					Name:        "Service",
-					Type:        "string",
-					Description: "the apex service of this split.",
-					JSONPath:    ".spec.service",
+		},
+	}
+}
+
+func (r *Reconciler) schema() *extensionv1.CustomResourceValidation {
+	return &extensionv1.CustomResourceValidation{
+		OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]extensionv1.JSONSchemaProps{
+				"spec": {
+					Type:     "object",
+					Required: []string{"service", "backends"},
+					Properties: map[string]extensionv1.JSONSchemaProps{
+						"service": {
+							Type:        "string",
+							Description: "The apex service of this split.",
+						},
+						"backends": {
+							Type:        "array",
+							Description: "The backend services of this split.",
+							Items: &extensionv1.JSONSchemaPropsOrArray{
+								Schema: &extensionv1.JSONSchemaProps{
+									Type:     "object",
+									Required: []string{"service", "weight"},
+									Properties: map[string]extensionv1.JSONSchemaProps{
+										"service": {
+											Type:        "string",
+											Description: "The kubernetes service of this backend.",
+										},
+										"weight": {
+											XIntOrString: true,
+											Description:  "The traffic weight of this backend, as a quantity.",
+										},
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
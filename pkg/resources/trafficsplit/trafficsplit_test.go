@@ -0,0 +1,28 @@
+package trafficsplit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	linkerdv1alpha1 "github.com/spaghettifunk/linkerd2-operator/api/v1alpha1"
+)
+
+func TestSchemasAreStructural(t *testing.T) {
+	r := New(nil, &linkerdv1alpha1.Linkerd{})
+	crd := r.crd().(*extensionv1.CustomResourceDefinition)
+
+	for _, version := range crd.Spec.Versions {
+		internal := &apiextensions.JSONSchemaProps{}
+		err := extensionv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema.OpenAPIV3Schema, internal, nil)
+		require.NoError(t, err, version.Name)
+
+		s, err := structuralschema.NewStructural(internal)
+		require.NoError(t, err, version.Name)
+		require.Empty(t, structuralschema.ValidateStructural(field.NewPath("openAPIV3Schema"), s), version.Name)
+	}
+}
@@ -50,7 +50,7 @@ func (r *Reconciler) deployment() runtime.Object {
 					TerminationGracePeriodSeconds: util.Int64Pointer(5),
 					ServiceAccountName:            serviceAccountName,
 					Containers:                    r.container(),
-					InitContainers:                templates.ProxyInitContainer(),
+					InitContainers:                templates.ProxyInitContainer(r.Config.Spec),
 					Volumes: []apiv1.Volume{
 						{
 							Name: "config",
@@ -83,17 +83,29 @@ func (r *Reconciler) container() []apiv1.Container {
 	objMeta, _ := meta.Accessor(obj)
 
 	apiAddr := fmt.Sprintf("-api-addr=linkerd-controller-api.%s.svc.cluster.local:8085", objMeta.GetNamespace())
-	grafanaAddr := fmt.Sprintf("-grafana-addr=linkerd-grafana.%s.svc.cluster.local:3000", objMeta.GetNamespace())
+	// the dashboard hides the grafana links when no address is given
+	grafanaAddr := "-grafana-addr="
+	if r.Config.Spec.Grafana.ExternalAddr != "" {
+		grafanaAddr = fmt.Sprintf("-grafana-addr=%s", r.Config.Spec.Grafana.ExternalAddr)
+	} else if r.Config.Spec.Grafana.IsDeployed() {
+		grafanaAddr = fmt.Sprintf("-grafana-addr=linkerd-grafana.%s.svc.cluster.local:3000", objMeta.GetNamespace())
+	}
 	controllerNamespace := fmt.Sprintf("-controller-namespace=%s", objMeta.GetNamespace())
 	enforcedHost := fmt.Sprintf("-enforced-host=^(localhost|127\\.0\\.0\\.1|linkerd-web\\.%s\\.svc\\.cluster\\.local|linkerd-web\\.%s\\.svc|\\[::1\\])(:\\d+)?$", objMeta.GetNamespace(), objMeta.GetNamespace())
 
+	args := []string{apiAddr, grafanaAddr, controllerNamespace, enforcedHost, "-log-level=info"}
+	if r.Config.Spec.Tracing.IsDeployed() && templates.Release(r.Config.Spec).Flags.JaegerAddr {
+		args = append(args, fmt.Sprintf("-jaeger-addr=linkerd-jaeger.%s.svc.cluster.local:16686", objMeta.GetNamespace()))
+	}
+	args = append(args, templates.TracingArgs(r.Config)...)
+
 	webConfig := r.Config.Spec.Web
 	containers := []apiv1.Container{
 		{
 			Name:            "web",
 			Image:           *webConfig.Image,
 			ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
-			Args:            []string{apiAddr, grafanaAddr, controllerNamespace, enforcedHost, "-log-level=info"},
+			Args:            args,
 			LivenessProbe:   templates.DefaultLivenessProbe("/ping", 9994, 10, 1),
 			ReadinessProbe:  templates.DefaultReadinessProbe("/ready", 9994, 7, 1),
 			Resources:       *webConfig.Resources,